@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"os"
@@ -13,10 +14,12 @@ import (
 
 func main() {
 	var proofmode bool
+	var securerun bool
 	var maxsteps uint64
 	var entrypointOffset uint64
 	var traceLocation string
 	var memoryLocation string
+	var coverageLocation string
 	var layoutName string
 	app := &cli.App{
 		Name:                 "cairo-vm",
@@ -35,6 +38,12 @@ func main() {
 						Required:    false,
 						Destination: &proofmode,
 					},
+					&cli.BoolFlag{
+						Name:        "securerun",
+						Usage:       "validates the builtin segments after the run",
+						Required:    false,
+						Destination: &securerun,
+					},
 					&cli.Uint64Flag{
 						Name:        "maxsteps",
 						Usage:       "limits the execution steps to 'maxsteps'",
@@ -61,6 +70,12 @@ func main() {
 						Required:    false,
 						Destination: &memoryLocation,
 					},
+					&cli.StringFlag{
+						Name:        "collect-coverage",
+						Usage:       "location to store the source coverage of the run, in lcov format",
+						Required:    false,
+						Destination: &coverageLocation,
+					},
 					&cli.StringFlag{
 						Name:        "layout",
 						Usage:       "specifies the set of builtins to be used",
@@ -91,12 +106,17 @@ func main() {
 						return fmt.Errorf("cannot load program: %w", err)
 					}
 
-					hints, err := hintrunner.GetZeroHints(cairoZeroJson)
+					hints, err := hintrunner.GetZeroHints(cairoZeroJson, nil)
 					if err != nil {
 						return fmt.Errorf("cannot create hints: %w", err)
 					}
 					fmt.Println("Running....")
-					runner, err := runnerzero.NewRunner(program, hints, proofmode, maxsteps, layoutName)
+					runner, err := runnerzero.NewRunner(program, hints, runnerzero.RunnerConfig{
+						ProofMode:       proofmode,
+						CollectCoverage: coverageLocation != "",
+						MaxSteps:        maxsteps,
+						Layout:          layoutName,
+					})
 					if err != nil {
 						return fmt.Errorf("cannot create runner: %w", err)
 					}
@@ -115,11 +135,35 @@ func main() {
 						}
 					}
 
+					if coverageLocation != "" {
+						pcs, err := runner.Coverage()
+						if err != nil {
+							return fmt.Errorf("cannot collect coverage: %w", err)
+						}
+						coverage, err := runnerzero.SourceCoverage(&cairoZeroJson.DebugInfo, pcs)
+						if err != nil {
+							return fmt.Errorf("cannot collect coverage: %w", err)
+						}
+						if err := os.WriteFile(coverageLocation, runnerzero.EncodeLcov(coverage), 0644); err != nil {
+							return fmt.Errorf("cannot write coverage: %w", err)
+						}
+					}
+
 					if proofmode {
 						runner.EndRun()
 						if err := runner.FinalizeSegments(); err != nil {
 							return fmt.Errorf("cannot finalize segments: %w", err)
 						}
+					}
+
+					// In proof mode, the padded and finalized segments are the ones checked
+					if securerun {
+						if err := runner.SecureRun(); err != nil {
+							return fmt.Errorf("secure run failed: %w", err)
+						}
+					}
+
+					if proofmode {
 						trace, memory, err := runner.BuildProof()
 						if err != nil {
 							return fmt.Errorf("cannot build proof: %w", err)
@@ -137,12 +181,19 @@ func main() {
 					}
 
 					fmt.Println("Success!")
-					output := runner.Output()
+					output, err := runner.Output()
+					// Programs without the output builtin have nothing to print
+					if errors.Is(err, runnerzero.ErrNoOutputBuiltin) {
+						return nil
+					}
+					if err != nil {
+						return fmt.Errorf("cannot get program output: %w", err)
+					}
 					if len(output) > 0 {
 						fmt.Println("Program output:")
-						for _, val := range output {
+						for i := range output {
 							// cairo-run v0.11-0.13 pad the output lines with two spaces.
-							fmt.Printf("  %s\n", val)
+							fmt.Printf("  %s\n", &output[i])
 						}
 					}
 					return nil
@@ -2,6 +2,7 @@ package assembler
 
 import (
 	"fmt"
+	"strings"
 
 	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 )
@@ -177,32 +178,45 @@ func (instr Instruction) Size() uint8 {
 	return 1
 }
 
+// String renders the instruction on a single line, showing the opcode, the cells
+// addressed by dst, op0 and op1 with their decoded offsets, and the res logic, pc update
+// and ap update flags, e.g.
+//
+//	opcode: Assert, dst: [ap + 0], op0: [fp - 1], op1: [pc + 1], res: Op1, pc update: Next instr, ap update: Add 1
 func (i Instruction) String() string {
-	return fmt.Sprintf(`
-        Dst Offset: %d
-        Dst Register: %s
-        Op0 Offset: %d
-        Op0 Register: %s
-        Op1 Offset: %d
-        Op1 Source: %s
-        ResLogic: %s
-        Pc Update: %s
-        Ap Update: %s
-        Opcode: %s
-    `,
-		i.OffDest,
-		i.DstRegister,
-		i.OffOp0,
-		i.Op0Register,
-		i.OffOp1,
-		i.Op1Source,
+	dst := formatCell(i.DstRegister.String(), i.OffDest)
+	op0 := formatCell(i.Op0Register.String(), i.OffOp0)
+
+	var op1 string
+	switch i.Op1Source {
+	case Op0:
+		op1 = formatCell(op0, i.OffOp1)
+	case Imm:
+		op1 = formatCell("pc", i.OffOp1)
+	default:
+		op1 = formatCell(i.Op1Source.String(), i.OffOp1)
+	}
+
+	return fmt.Sprintf(
+		"opcode: %s, dst: %s, op0: %s, op1: %s, res: %s, pc update: %s, ap update: %s",
+		i.Opcode,
+		dst,
+		op0,
+		op1,
 		i.Res,
 		i.PcUpdate,
 		i.ApUpdate,
-		i.Opcode,
 	)
 }
 
+// formatCell renders the memory cell at `base + offset`, e.g. `[fp - 3]`
+func formatCell(base string, offset int16) string {
+	if offset < 0 {
+		return fmt.Sprintf("[%s - %d]", strings.ToLower(base), -int32(offset))
+	}
+	return fmt.Sprintf("[%s + %d]", strings.ToLower(base), offset)
+}
+
 const (
 	// Offsets
 	op0Offset   = 16
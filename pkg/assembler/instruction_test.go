@@ -152,6 +152,58 @@ func TestAddAp(t *testing.T) {
 	assert.Equal(t, expected, *decoded)
 }
 
+func TestInstructionString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		encoding []byte
+		expected string
+	}{
+		{
+			name:     "assert eq with immediate",
+			encoding: []byte{0x48, 0x06, 0x80, 0x01, 0x7F, 0xFF, 0x80, 0x00},
+			expected: "opcode: Assert, dst: [ap + 0], op0: [fp - 1], op1: [pc + 1], res: Op1, pc update: Next instr, ap update: Add 1",
+		},
+		{
+			name:     "assert eq with double dereference",
+			encoding: []byte{0x40, 0x02, 0x80, 0x02, 0x7F, 0xFD, 0x80, 0x00},
+			expected: "opcode: Assert, dst: [ap + 0], op0: [fp - 3], op1: [[fp - 3] + 2], res: Op1, pc update: Next instr, ap update: Same Ap",
+		},
+		{
+			name:     "relative jump",
+			encoding: []byte{0x01, 0x29, 0x80, 0x00, 0x80, 0x02, 0x7F, 0xFF},
+			expected: "opcode: Nop, dst: [fp - 1], op0: [ap + 2], op1: [fp + 0], res: Add, pc update: Jump Rel, ap update: Same Ap",
+		},
+		{
+			name:     "jnz",
+			encoding: []byte{0x02, 0x0A, 0x7F, 0xF0, 0x7F, 0xFF, 0x80, 0x03},
+			expected: "opcode: Nop, dst: [ap + 3], op0: [fp - 1], op1: [fp - 16], res: Unconstrained, pc update: Jnz, ap update: Same Ap",
+		},
+		{
+			name:     "call",
+			encoding: []byte{0x11, 0x04, 0x80, 0x01, 0x80, 0x01, 0x80, 0x00},
+			expected: "opcode: Call, dst: [ap + 0], op0: [ap + 1], op1: [pc + 1], res: Op1, pc update: Jump Rel, ap update: Add 2",
+		},
+		{
+			name:     "ret",
+			encoding: []byte{0x20, 0x8B, 0x7F, 0xFF, 0x7F, 0xFF, 0x7F, 0xFE},
+			expected: "opcode: Ret, dst: [fp - 2], op0: [fp - 1], op1: [fp - 1], res: Op1, pc update: Jump Abs, ap update: Same Ap",
+		},
+		{
+			name:     "add ap",
+			encoding: []byte{0x04, 0x07, 0x80, 0x01, 0x7F, 0xFF, 0x7F, 0xFF},
+			expected: "opcode: Nop, dst: [fp - 1], op0: [fp - 1], op1: [pc + 1], res: Op1, pc update: Next instr, ap update: Add Res",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := DecodeInstruction(new(f.Element).SetBytes(tc.encoding))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, decoded.String())
+		})
+	}
+}
+
 func TestBiggerThan64Bits(t *testing.T) {
 	instruction := new(f.Element).SetBigInt(big.NewInt(1).Lsh(big.NewInt(1), 64))
 
@@ -0,0 +1,40 @@
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+// HashScheme selects the hash function used to hash an array of felts
+type HashScheme uint8
+
+const (
+	// HashSchemePedersen hashes arrays with PedersenArray
+	HashSchemePedersen HashScheme = iota
+	// HashSchemePoseidon hashes arrays with PoseidonArray
+	HashSchemePoseidon
+)
+
+func (scheme HashScheme) String() string {
+	switch scheme {
+	case HashSchemePedersen:
+		return "pedersen"
+	case HashSchemePoseidon:
+		return "poseidon"
+	default:
+		return fmt.Sprintf("HashScheme(%d)", uint8(scheme))
+	}
+}
+
+// HashArray returns the hash of `elems` with the given scheme
+func HashArray(elems []fp.Element, scheme HashScheme) (fp.Element, error) {
+	switch scheme {
+	case HashSchemePedersen:
+		return PedersenArray(elems...), nil
+	case HashSchemePoseidon:
+		return PoseidonArray(elems...), nil
+	default:
+		return fp.Element{}, fmt.Errorf("unknown hash scheme %s", scheme)
+	}
+}
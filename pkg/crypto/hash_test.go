@@ -0,0 +1,71 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoseidonPerm(t *testing.T) {
+	// poseidon_hash(1, 2) is the first cell of the permutation of (1, 2, 2)
+	x := feltFromString(t, "1")
+	y := feltFromString(t, "2")
+	z := feltFromString(t, "2")
+
+	state := PoseidonPerm(&x, &y, &z)
+	assert.Equal(t, feltFromString(t, "0x5d44a3decb2b2e0cc71071f7b802f45dd792d064f0fc7316c46514f70f9891a"), state[0])
+}
+
+func TestPoseidonArray(t *testing.T) {
+	one := feltFromString(t, "1")
+	zero := fp.Element{}
+
+	// the empty array is padded to (1, 0)
+	assert.Equal(t, PoseidonPerm(&one, &zero, &zero)[0], PoseidonArray())
+	assert.Equal(t, feltFromString(t, "0x2272be0f580fd156823304800919530eaa97430e972d7213ee13f4fbf7a5dbc"), PoseidonArray())
+
+	// an odd-length array only needs the one as padding
+	assert.Equal(t, PoseidonPerm(&one, &one, &zero)[0], PoseidonArray(one))
+
+	elems := []fp.Element{
+		feltFromString(t, "1"),
+		feltFromString(t, "2"),
+		feltFromString(t, "3"),
+		feltFromString(t, "4"),
+	}
+	assert.Equal(t, feltFromString(t, "0x26e3ad8b876e02bc8a4fc43dad40a8f81a6384083cabffa190bcf40d512ae1d"), PoseidonArray(elems...))
+	// the input slice is left untouched by the padding
+	assert.Len(t, elems, 4)
+}
+
+func TestHashArray(t *testing.T) {
+	elems := []fp.Element{
+		feltFromString(t, "1"),
+		feltFromString(t, "2"),
+		feltFromString(t, "3"),
+		feltFromString(t, "4"),
+	}
+
+	tests := []struct {
+		scheme   HashScheme
+		elems    []fp.Element
+		expected fp.Element
+	}{
+		{HashSchemePedersen, elems, feltFromString(t, "0x66bd4335902683054d08a0572747ea78ebd9e531536fb43125424ca9f902084")},
+		{HashSchemePedersen, nil, PedersenArray()},
+		{HashSchemePoseidon, elems, feltFromString(t, "0x26e3ad8b876e02bc8a4fc43dad40a8f81a6384083cabffa190bcf40d512ae1d")},
+		{HashSchemePoseidon, nil, feltFromString(t, "0x2272be0f580fd156823304800919530eaa97430e972d7213ee13f4fbf7a5dbc")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scheme.String(), func(t *testing.T) {
+			hash, err := HashArray(test.elems, test.scheme)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, hash)
+		})
+	}
+
+	_, err := HashArray(elems, HashScheme(2))
+	assert.ErrorContains(t, err, "unknown hash scheme HashScheme(2)")
+}
@@ -0,0 +1,22 @@
+package crypto
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	pedersenhash "github.com/consensys/gnark-crypto/ecc/stark-curve/pedersen-hash"
+)
+
+// Pedersen returns the stark curve Pedersen hash of `a` and `b`
+func Pedersen(a, b fp.Element) fp.Element {
+	return pedersenhash.Pedersen(&a, &b)
+}
+
+// PedersenArray returns the chained Pedersen hash of `elems`, as computed by
+// `compute_hash_on_elements` to hash structs: every element is hashed into an
+// accumulator starting at zero, which is finally hashed with the number of elements
+func PedersenArray(elems ...fp.Element) fp.Element {
+	var hash fp.Element
+	for _, elem := range elems {
+		hash = Pedersen(hash, elem)
+	}
+	return Pedersen(hash, *new(fp.Element).SetUint64(uint64(len(elems))))
+}
@@ -0,0 +1,54 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/assert"
+)
+
+func feltFromString(t *testing.T, s string) fp.Element {
+	felt, err := new(fp.Element).SetString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *felt
+}
+
+func TestPedersen(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected string
+	}{
+		{
+			a:        "1",
+			b:        "2",
+			expected: "0x5bb9440e27889a364bcb678b1f679ecd1347acdedcbf36e83494f857cc58026",
+		},
+		{
+			a:        "0x03d937c035c878245caf64531a5756109c53068da139362728feb561405371cb",
+			b:        "0x0208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a",
+			expected: "0x30e480bed5fe53fa909cc0f8c4d99b8f9f2c016be4c41e13a4848797979c662",
+		},
+	}
+
+	for _, test := range tests {
+		hash := Pedersen(feltFromString(t, test.a), feltFromString(t, test.b))
+		assert.Equal(t, feltFromString(t, test.expected), hash)
+	}
+}
+
+func TestPedersenArray(t *testing.T) {
+	elems := []fp.Element{
+		feltFromString(t, "1"),
+		feltFromString(t, "2"),
+		feltFromString(t, "3"),
+		feltFromString(t, "4"),
+	}
+
+	hash := PedersenArray(elems...)
+	assert.Equal(t, feltFromString(t, "0x66bd4335902683054d08a0572747ea78ebd9e531536fb43125424ca9f902084"), hash)
+
+	// the empty array hashes its length with the zero accumulator
+	assert.Equal(t, Pedersen(fp.Element{}, fp.Element{}), PedersenArray())
+}
@@ -1,4 +1,4 @@
-package builtins
+package crypto
 
 import (
 	"sync"
@@ -56,12 +56,34 @@ func hadesPermutation(state []fp.Element) {
 	}
 }
 
+// PoseidonPerm returns the state resulting from the Hades permutation of (x, y, z)
 func PoseidonPerm(x, y, z *fp.Element) []fp.Element {
 	state := []fp.Element{*x, *y, *z}
 	hadesPermutation(state)
 	return state
 }
 
+// PoseidonArray returns the Poseidon hash of `elems`, as computed by `poseidon_hash_many`:
+// the elements are padded with a one and, if needed, a zero to an even length, then
+// absorbed two at a time into the first two cells of the state, which is permuted
+// after each pair. The hash is the first cell of the final state
+func PoseidonArray(elems ...fp.Element) fp.Element {
+	padded := make([]fp.Element, len(elems), len(elems)+2)
+	copy(padded, elems)
+	padded = append(padded, *new(fp.Element).SetOne())
+	if len(padded)%2 != 0 {
+		padded = append(padded, fp.Element{})
+	}
+
+	state := make([]fp.Element, 3)
+	for i := 0; i < len(padded); i += 2 {
+		state[0].Add(&state[0], &padded[i])
+		state[1].Add(&state[1], &padded[i+1])
+		hadesPermutation(state)
+	}
+	return state[0]
+}
+
 var (
 	initialiseRoundKeys sync.Once
 	roundKeys           = [][]fp.Element{}
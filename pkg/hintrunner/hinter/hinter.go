@@ -13,6 +13,12 @@ type Hinter interface {
 	Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error
 }
 
+// OperandDeclarer is optionally implemented by the hints that declare the names of
+// the operands they read or write, so that they can be checked when loading a program
+type OperandDeclarer interface {
+	DeclaredOperands() []string
+}
+
 // Global context to keep track of different results across different
 // hints execution.
 type HintRunnerContext struct {
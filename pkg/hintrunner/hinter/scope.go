@@ -65,6 +65,32 @@ func (sm *ScopeManager) AssignVariables(values map[string]any) error {
 	return nil
 }
 
+// UpdateVariable sets the value of `name` in the nearest scope that already
+// holds it, unlike AssignVariable which always writes to the current scope
+func (sm *ScopeManager) UpdateVariable(name string, value any) error {
+	for i := len(sm.scopes) - 1; i >= 0; i-- {
+		if _, ok := sm.scopes[i][name]; ok {
+			sm.scopes[i][name] = value
+			return nil
+		}
+	}
+	return fmt.Errorf("variable %s not found in any scope", name)
+}
+
+// Variables returns a copy of all the variables visible from the current scope,
+// a variable shadowed by a nearer scope being reported with its nearest value.
+// It is meant for debugging, hints read the current scope or the nearest scope
+// holding a variable
+func (sm *ScopeManager) Variables() map[string]any {
+	variables := make(map[string]any)
+	for _, scope := range sm.scopes {
+		for name, value := range scope {
+			variables[name] = value
+		}
+	}
+	return variables
+}
+
 func (sm *ScopeManager) DeleteVariable(name string) error {
 	scope, err := sm.getCurrentScope()
 	if err != nil {
@@ -88,6 +114,28 @@ func (sm *ScopeManager) GetVariableValue(name string) (any, error) {
 	return nil, fmt.Errorf("variable %s not found in current scope", name)
 }
 
+// GetVariableValueFromAnyScope returns the value of `name` in the nearest scope that
+// holds it, unlike GetVariableValue which only reads the current scope
+func (sm *ScopeManager) GetVariableValueFromAnyScope(name string) (any, error) {
+	for i := len(sm.scopes) - 1; i >= 0; i-- {
+		if value, ok := sm.scopes[i][name]; ok {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("variable %s not found in any scope", name)
+}
+
+// DeleteVariableFromAnyScope removes `name` from the nearest scope that holds it
+func (sm *ScopeManager) DeleteVariableFromAnyScope(name string) error {
+	for i := len(sm.scopes) - 1; i >= 0; i-- {
+		if _, ok := sm.scopes[i][name]; ok {
+			delete(sm.scopes[i], name)
+			return nil
+		}
+	}
+	return fmt.Errorf("variable %s not found in any scope", name)
+}
+
 func (sm *ScopeManager) GetVariableValueAsBigInt(name string) (*big.Int, error) {
 	value, err := sm.GetVariableValue(name)
 	if err != nil {
@@ -39,3 +39,71 @@ func TestScope(t *testing.T) {
 	err = sm.ExitScope()
 	require.ErrorContains(t, err, "expected at least one existing scope")
 }
+
+func TestScopeUpdateVariable(t *testing.T) {
+	sm := DefaultNewScopeManager()
+	require.NoError(t, sm.AssignVariable("n", 3))
+
+	// Updating a variable of a parent scope mutates it in place,
+	// without creating it in the current scope
+	sm.EnterScope(map[string]any{})
+	require.NoError(t, sm.UpdateVariable("n", 4))
+	_, err := sm.GetVariableValue("n")
+	require.ErrorContains(t, err, "variable n not found in current scope")
+
+	// Assigning creates the variable in the current scope, which shadows the
+	// parent one, and updating now mutates the nearest variable only
+	require.NoError(t, sm.AssignVariable("n", 5))
+	require.NoError(t, sm.UpdateVariable("n", 6))
+	n, err := sm.GetVariableValue("n")
+	require.NoError(t, err)
+	require.Equal(t, 6, n)
+
+	require.NoError(t, sm.ExitScope())
+	n, err = sm.GetVariableValue("n")
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+
+	// Updating a variable that has not been defined
+	err = sm.UpdateVariable("x", 1)
+	require.ErrorContains(t, err, "variable x not found in any scope")
+}
+
+func TestScopeVariables(t *testing.T) {
+	sm := NewScopeManager(map[string]any{"n": 1, "m": 2})
+	sm.EnterScope(map[string]any{"n": 3})
+	sm.EnterScope(map[string]any{"x": 4})
+
+	// The nearest scope wins for shadowed variables
+	variables := sm.Variables()
+	require.Equal(t, map[string]any{"n": 3, "m": 2, "x": 4}, variables)
+
+	// The snapshot is a copy, mutating it leaves the scopes untouched
+	variables["x"] = 5
+	delete(variables, "m")
+	require.Equal(t, map[string]any{"n": 3, "m": 2, "x": 4}, sm.Variables())
+
+	require.NoError(t, sm.ExitScope())
+	require.NoError(t, sm.ExitScope())
+	require.Equal(t, map[string]any{"n": 1, "m": 2}, sm.Variables())
+}
+
+func TestScopeAnyScopeVariable(t *testing.T) {
+	sm := NewScopeManager(map[string]any{"n": 1})
+	sm.EnterScope(map[string]any{})
+
+	// The variable of a parent scope is found and deleted from the current one
+	n, err := sm.GetVariableValueFromAnyScope("n")
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.NoError(t, sm.DeleteVariableFromAnyScope("n"))
+
+	require.NoError(t, sm.ExitScope())
+	_, err = sm.GetVariableValue("n")
+	require.ErrorContains(t, err, "variable n not found in current scope")
+
+	_, err = sm.GetVariableValueFromAnyScope("n")
+	require.ErrorContains(t, err, "variable n not found in any scope")
+	err = sm.DeleteVariableFromAnyScope("n")
+	require.ErrorContains(t, err, "variable n not found in any scope")
+}
@@ -49,3 +49,24 @@ func ResolveAsUint64(vm *VM.VirtualMachine, op ResOperander) (uint64, error) {
 
 	return uint64Value, nil
 }
+
+// MemEqualRange compares the `n` consecutive memory cells starting at `addrA`
+// to the ones starting at `addrB`. It errors if one of the cells is unknown
+func MemEqualRange(vm *VM.VirtualMachine, addrA, addrB mem.MemoryAddress, n uint64) (bool, error) {
+	for i := uint64(0); i < n; i++ {
+		a := mem.MemoryAddress{SegmentIndex: addrA.SegmentIndex, Offset: addrA.Offset + i}
+		aValue, err := vm.Memory.ReadFromAddress(&a)
+		if err != nil {
+			return false, err
+		}
+		b := mem.MemoryAddress{SegmentIndex: addrB.SegmentIndex, Offset: addrB.Offset + i}
+		bValue, err := vm.Memory.ReadFromAddress(&b)
+		if err != nil {
+			return false, err
+		}
+		if !aValue.Equal(&bValue) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,37 @@
+package hinter
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/utils"
+	VM "github.com/NethermindEth/cairo-vm-go/pkg/vm"
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemEqualRange(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	for offset, value := range []int64{1, 2, 3, 1, 2, 3, 1, 2, 4} {
+		utils.WriteTo(vm, VM.ExecutionSegment, uint64(offset), memory.MemoryValueFromInt(value))
+	}
+	addr := func(offset uint64) memory.MemoryAddress {
+		return memory.MemoryAddress{SegmentIndex: VM.ExecutionSegment, Offset: offset}
+	}
+
+	equal, err := MemEqualRange(vm, addr(0), addr(3), 3)
+	require.NoError(t, err)
+	require.True(t, equal)
+
+	equal, err = MemEqualRange(vm, addr(0), addr(6), 3)
+	require.NoError(t, err)
+	require.False(t, equal)
+
+	// the ranges only differ on their last cell
+	equal, err = MemEqualRange(vm, addr(0), addr(6), 2)
+	require.NoError(t, err)
+	require.True(t, equal)
+
+	// offset 9 was never written
+	_, err = MemEqualRange(vm, addr(6), addr(9), 1)
+	require.ErrorContains(t, err, "unknown value")
+}
@@ -86,6 +86,22 @@ func (dm *ZeroDictionaryManager) NewDefaultDictionary(vm *VM.VirtualMachine, def
 	return newDefaultDictAddr
 }
 
+// It creates a new segment which will hold dictionary values. It links this
+// segment with the current dictionary and returns the address that points
+// to the start of this segment. Initial dictionary data is set from the data argument
+// and if key not present in the dictionary during querying the defaultValue will be
+// returned instead.
+func (dm *ZeroDictionaryManager) NewDefaultDictionaryWithData(vm *VM.VirtualMachine, defaultValue mem.MemoryValue, data map[fp.Element]mem.MemoryValue) mem.MemoryAddress {
+	newDefaultDictAddr := vm.Memory.AllocateEmptySegment()
+	freeOffset := uint64(0)
+	dm.Dictionaries[newDefaultDictAddr.SegmentIndex] = ZeroDictionary{
+		Data:         &data,
+		DefaultValue: &defaultValue,
+		FreeOffset:   &freeOffset,
+	}
+	return newDefaultDictAddr
+}
+
 // Given a memory address, it looks for the right dictionary using the segment index. If no
 // segment is associated with the given segment index, it errors
 func (dm *ZeroDictionaryManager) GetDictionary(dictAddr mem.MemoryAddress) (ZeroDictionary, error) {
@@ -142,3 +158,50 @@ func (dm *ZeroDictionaryManager) SetFreeOffset(dictAddr mem.MemoryAddress, freeO
 	dict.setFreeOffset(freeOffset)
 	return nil
 }
+
+// Given a memory address, it writes the `(key, prev_value, new_value)` DictAccess at the
+// free offset of the dictionary, which the address must point to, and advances it by
+// the size of a DictAccess. This keeps the access log consistent for `squash_dict`
+func (dm *ZeroDictionaryManager) AppendAccess(vm *VM.VirtualMachine, dictAddr mem.MemoryAddress, key fp.Element, prevValue, newValue mem.MemoryValue) error {
+	dict, err := dm.GetDictionary(dictAddr)
+	if err != nil {
+		return err
+	}
+
+	access := []mem.MemoryValue{mem.MemoryValueFromFieldElement(&key), prevValue, newValue}
+	for i := range access {
+		if err := vm.Memory.WriteToNthStructField(dictAddr, access[i], int16(i)); err != nil {
+			return fmt.Errorf("writing dict access at %s: %w", dictAddr, err)
+		}
+	}
+
+	dict.incrementFreeOffset(uint64(len(access)))
+	return nil
+}
+
+// DumpAll returns a copy of the data held by every dictionary, keyed by the segment
+// index of the dictionary. Keys only reachable through a default value are not part
+// of the dump, `DefaultValues` gives these values separately
+func (dm *ZeroDictionaryManager) DumpAll() map[uint64]map[fp.Element]mem.MemoryValue {
+	dump := make(map[uint64]map[fp.Element]mem.MemoryValue, len(dm.Dictionaries))
+	for segmentIndex, dict := range dm.Dictionaries {
+		data := make(map[fp.Element]mem.MemoryValue, len(*dict.Data))
+		for key, value := range *dict.Data {
+			data[key] = value
+		}
+		dump[segmentIndex] = data
+	}
+	return dump
+}
+
+// DefaultValues returns the default value of every default dictionary, keyed by the
+// segment index of the dictionary
+func (dm *ZeroDictionaryManager) DefaultValues() map[uint64]mem.MemoryValue {
+	defaultValues := make(map[uint64]mem.MemoryValue)
+	for segmentIndex, dict := range dm.Dictionaries {
+		if *dict.DefaultValue != mem.UnknownValue {
+			defaultValues[segmentIndex] = *dict.DefaultValue
+		}
+	}
+	return defaultValues
+}
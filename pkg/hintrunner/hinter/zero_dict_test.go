@@ -0,0 +1,81 @@
+package hinter
+
+import (
+	"testing"
+
+	VM "github.com/NethermindEth/cairo-vm-go/pkg/vm"
+	mem "github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeroDictAppendAccess(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	dm := NewZeroDictionaryManager()
+	dictAddr := dm.NewDefaultDictionary(vm, mem.MemoryValueFromUint(uint64(0)))
+
+	accesses := [][3]uint64{
+		{1, 0, 10},
+		{2, 0, 20},
+		{1, 10, 11},
+	}
+	for i, access := range accesses {
+		accessAddr := mem.MemoryAddress{SegmentIndex: dictAddr.SegmentIndex, Offset: uint64(3 * i)}
+		err := dm.AppendAccess(
+			vm,
+			accessAddr,
+			*new(fp.Element).SetUint64(access[0]),
+			mem.MemoryValueFromUint(access[1]),
+			mem.MemoryValueFromUint(access[2]),
+		)
+		require.NoError(t, err)
+	}
+
+	segment := vm.Memory.Segments[dictAddr.SegmentIndex]
+	require.Equal(t, uint64(9), segment.Len())
+	for i, access := range accesses {
+		for j, expected := range access {
+			value, err := segment.Read(uint64(3*i + j))
+			require.NoError(t, err)
+			require.Equal(t, mem.MemoryValueFromUint(expected), value)
+		}
+	}
+
+	dict, err := dm.GetDictionary(mem.MemoryAddress{SegmentIndex: dictAddr.SegmentIndex, Offset: 9})
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), *dict.FreeOffset)
+
+	// Accesses can only be appended at the free offset
+	err = dm.AppendAccess(vm, dictAddr, fp.Element{}, mem.MemoryValueFromUint(uint64(0)), mem.MemoryValueFromUint(uint64(0)))
+	require.ErrorContains(t, err, "no dictionary at address")
+}
+
+func TestZeroDictDumpAll(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	dm := NewZeroDictionaryManager()
+	dictAddr := dm.NewDictionary(vm, map[fp.Element]mem.MemoryValue{
+		*new(fp.Element).SetUint64(1): mem.MemoryValueFromUint(uint64(10)),
+	})
+	defaultDictAddr := dm.NewDefaultDictionary(vm, mem.MemoryValueFromUint(uint64(7)))
+	require.NoError(t, dm.Set(dictAddr, *new(fp.Element).SetUint64(2), mem.MemoryValueFromUint(uint64(20))))
+	require.NoError(t, dm.Set(defaultDictAddr, *new(fp.Element).SetUint64(3), mem.MemoryValueFromUint(uint64(30))))
+
+	dump := dm.DumpAll()
+	require.Equal(t, map[uint64]map[fp.Element]mem.MemoryValue{
+		dictAddr.SegmentIndex: {
+			*new(fp.Element).SetUint64(1): mem.MemoryValueFromUint(uint64(10)),
+			*new(fp.Element).SetUint64(2): mem.MemoryValueFromUint(uint64(20)),
+		},
+		defaultDictAddr.SegmentIndex: {
+			*new(fp.Element).SetUint64(3): mem.MemoryValueFromUint(uint64(30)),
+		},
+	}, dump)
+	require.Equal(t, map[uint64]mem.MemoryValue{
+		defaultDictAddr.SegmentIndex: mem.MemoryValueFromUint(uint64(7)),
+	}, dm.DefaultValues())
+
+	// the dump is a copy of the dictionaries
+	dump[dictAddr.SegmentIndex][*new(fp.Element).SetUint64(4)] = mem.MemoryValueFromUint(uint64(40))
+	_, err := dm.At(dictAddr, *new(fp.Element).SetUint64(4))
+	require.ErrorContains(t, err, "no value for key")
+}
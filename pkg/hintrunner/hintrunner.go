@@ -23,6 +23,20 @@ func NewHintRunner(hints map[uint64][]h.Hinter) HintRunner {
 	}
 }
 
+// NewHintRunnerWithGlobals is like NewHintRunner, but the root scope of the
+// hints starts with the given variables
+func NewHintRunnerWithGlobals(hints map[uint64][]h.Hinter, globals map[string]any) HintRunner {
+	hintRunner := NewHintRunner(hints)
+	hintRunner.context.ScopeManager = *h.NewScopeManager(globals)
+	return hintRunner
+}
+
+// ZeroDictionaryManager returns the dictionary manager created by the Cairo Zero
+// dictionary hints, if any of them ran
+func (hr *HintRunner) ZeroDictionaryManager() (h.ZeroDictionaryManager, bool) {
+	return hr.context.ScopeManager.GetZeroDictionaryManager()
+}
+
 func (hr *HintRunner) RunHint(vm *VM.VirtualMachine) error {
 	hints := hr.hints[vm.Context.Pc.Offset]
 	if len(hints) == 0 {
@@ -29,6 +29,16 @@ func GetN() (big.Int, bool) {
 func SecPPacked(limbs [3]*fp.Element) (big.Int, error) {
 	// https://github.com/starkware-libs/cairo-lang/blob/efa9648f57568aad8f8a13fbf027d2de7c63c2c0/src/starkware/cairo/common/cairo_secp/secp_utils.py#L28
 
+	return packLimbs(limbs[:])
+}
+
+// SecPPacked5 packs the 5 limbs of an unreduced value, such as the product of two
+// 3-limb values, the same way `SecPPacked` does for 3 limbs
+func SecPPacked5(limbs [5]*fp.Element) (big.Int, error) {
+	return packLimbs(limbs[:])
+}
+
+func packLimbs(limbs []*fp.Element) (big.Int, error) {
 	baseBig, ok := getBaseBig()
 	if !ok {
 		return *big.NewInt(0), fmt.Errorf("getBaseBig failed")
@@ -73,6 +83,35 @@ func SecPSplit(num *big.Int) ([]big.Int, error) {
 	return split, nil
 }
 
+// SecPSplit5 splits a non-negative value, such as the product of two 3-limb values,
+// in 5 limbs: the first 4 are reduced modulo the base and the last one holds the rest
+// of the value, so that `SecPPacked5` gives the value back. Unlike `SecPSplit`, `num`
+// isn't modified
+func SecPSplit5(num *big.Int) ([]big.Int, error) {
+	if num.Sign() < 0 {
+		return nil, fmt.Errorf("cannot split negative value %v", num)
+	}
+
+	baseBig, ok := getBaseBig()
+	if !ok {
+		return nil, fmt.Errorf("GetBaseBig failed")
+	}
+
+	split := make([]big.Int, 5)
+	rest := new(big.Int).Set(num)
+	for i := 0; i < 4; i++ {
+		rest.DivMod(rest, &baseBig, &split[i])
+	}
+
+	// the last limb is packed as a signed felt, it must stay below PRIME // 2
+	if rest.Cmp(new(big.Int).Rsh(fp.Modulus(), 1)) >= 0 {
+		return nil, fmt.Errorf("%v is too big to be split in 5 limbs", num)
+	}
+	split[4] = *rest
+
+	return split, nil
+}
+
 func GetSecp256R1_P() (big.Int, bool) {
 	// 2**256 - 2**224 + 2**192 + 2**96 - 1
 	secp256R1_P, ok := new(big.Int).SetString("115792089210356248762697446949407573530086143415290314195533631308867097853951", 10)
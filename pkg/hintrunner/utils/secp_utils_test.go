@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecPSplit5(t *testing.T) {
+	secP, ok := GetSecPBig()
+	require.True(t, ok)
+
+	// the product of two 3-limb values
+	x := new(big.Int).Sub(&secP, big.NewInt(1))
+	y := new(big.Int).Sub(&secP, big.NewInt(2))
+	product := new(big.Int).Mul(x, y)
+
+	limbs, err := SecPSplit5(product)
+	require.NoError(t, err)
+	expected := []string{
+		"18446752478961507350",
+		"0",
+		"58028439341502200385896448",
+		"77371252455336265033711126",
+		"374144419156711147060143317175368453031918731001855",
+	}
+	require.Len(t, limbs, len(expected))
+	for i := range limbs {
+		require.Equal(t, expected[i], limbs[i].String())
+	}
+
+	var limbFelts [5]*fp.Element
+	for i := range limbs {
+		limbFelts[i] = new(fp.Element).SetBigInt(&limbs[i])
+	}
+	packed, err := SecPPacked5(limbFelts)
+	require.NoError(t, err)
+	require.Equal(t, 0, packed.Cmp(product))
+	// the value isn't modified by the split
+	require.Equal(t, 0, product.Cmp(new(big.Int).Mul(x, y)))
+
+	_, err = SecPSplit5(big.NewInt(-1))
+	require.ErrorContains(t, err, "cannot split negative value -1")
+
+	tooBig := new(big.Int).Lsh(fp.Modulus(), 344)
+	_, err = SecPSplit5(tooBig)
+	require.ErrorContains(t, err, "is too big to be split in 5 limbs")
+}
@@ -5,15 +5,22 @@ import (
 	"strings"
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/hinter"
+	"github.com/NethermindEth/cairo-vm-go/pkg/parsers/zero"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 )
 
 type hintReferenceResolver struct {
 	refs map[string]hinter.Reference
+
+	// identifiers and scopes are used to look up program constants
+	// (e.g. `const SHIFT = 2 ** 128;`) that are visible from the hint
+	identifiers map[string]*zero.Identifier
+	scopes      []string
 }
 
 func NewReferenceResolver() hintReferenceResolver {
 	refs := make(map[string]hinter.Reference)
-	return hintReferenceResolver{refs}
+	return hintReferenceResolver{refs: refs}
 }
 
 func (m *hintReferenceResolver) AddReference(name string, v hinter.Reference) error {
@@ -26,10 +33,21 @@ func (m *hintReferenceResolver) AddReference(name string, v hinter.Reference) er
 	return nil
 }
 
+// SetConstants makes the `const` identifiers declared in any of the
+// accessible scopes resolvable by their short name. References always
+// take precedence over constants.
+func (m *hintReferenceResolver) SetConstants(identifiers map[string]*zero.Identifier, accessibleScopes []string) {
+	m.identifiers = identifiers
+	m.scopes = accessibleScopes
+}
+
 func (m *hintReferenceResolver) GetReference(name string) (hinter.Reference, error) {
 	if v, ok := m.refs[name]; ok {
 		return v, nil
 	}
+	if v, ok := m.getConstant(name); ok {
+		return v, nil
+	}
 	return nil, fmt.Errorf("missing reference %s", name)
 }
 
@@ -47,6 +65,17 @@ func (m *hintReferenceResolver) GetResOperander(name string) (hinter.ResOperande
 	return op, nil
 }
 
+// getOptionalResOperander behaves like GetResOperander, except that it returns a nil
+// operander and no error when `name` is neither a reference nor a constant
+func (m *hintReferenceResolver) getOptionalResOperander(name string) (hinter.ResOperander, error) {
+	if _, ok := m.refs[name]; !ok {
+		if _, ok := m.getConstant(name); !ok {
+			return nil, nil
+		}
+	}
+	return m.GetResOperander(name)
+}
+
 func (m *hintReferenceResolver) GetCellRefer(name string) (hinter.CellRefer, error) {
 	ref, err := m.GetReference(name)
 	if err != nil {
@@ -59,6 +88,36 @@ func (m *hintReferenceResolver) GetCellRefer(name string) (hinter.CellRefer, err
 	return op, nil
 }
 
+// CheckDeclaredOperands verifies that every operand declared by the hint resolves
+// to a reference or a constant. Hints that don't declare their operands always pass
+func (m *hintReferenceResolver) CheckDeclaredOperands(hint hinter.Hinter) error {
+	declarer, ok := hint.(hinter.OperandDeclarer)
+	if !ok {
+		return nil
+	}
+	for _, name := range declarer.DeclaredOperands() {
+		if _, err := m.GetReference(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getConstant looks for a `const` identifier named `name`, starting from the
+// innermost accessible scope.
+func (m *hintReferenceResolver) getConstant(name string) (hinter.Reference, bool) {
+	for i := len(m.scopes) - 1; i >= 0; i-- {
+		identifier, ok := m.identifiers[m.scopes[i]+"."+name]
+		if !ok || identifier.IdentifierType != "const" || identifier.Value == nil {
+			continue
+		}
+		var value fp.Element
+		value.SetBigInt(identifier.Value)
+		return hinter.Immediate(value), true
+	}
+	return nil, false
+}
+
 // shortSymbolName turns a full symbol name like "a.b.c" into just "c".
 func shortSymbolName(name string) string {
 	i := strings.LastIndexByte(name, '.')
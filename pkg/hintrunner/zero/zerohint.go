@@ -13,17 +13,25 @@ import (
 type GenericZeroHinter struct {
 	Name string
 	Op   func(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error
+	// Operands optionally lists the names of the references the hint uses
+	Operands []string
 }
 
 func (hint *GenericZeroHinter) String() string {
 	return hint.Name
 }
 
+func (hint *GenericZeroHinter) DeclaredOperands() []string {
+	return hint.Operands
+}
+
 func (hint *GenericZeroHinter) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
 	return hint.Op(vm, ctx)
 }
 
-func GetZeroHints(cairoZeroJson *zero.ZeroProgram) (map[uint64][]hinter.Hinter, error) {
+// GetZeroHints creates the hinters of every hint of the program. `customHints` can
+// be nil, when the program only uses built-in hints
+func GetZeroHints(cairoZeroJson *zero.ZeroProgram, customHints CustomHints) (map[uint64][]hinter.Hinter, error) {
 	hints := make(map[uint64][]hinter.Hinter)
 	for counter, rawHints := range cairoZeroJson.Hints {
 		pc, err := strconv.ParseUint(counter, 10, 64)
@@ -32,7 +40,7 @@ func GetZeroHints(cairoZeroJson *zero.ZeroProgram) (map[uint64][]hinter.Hinter,
 		}
 
 		for _, rawHint := range rawHints {
-			hint, err := GetHintFromCode(cairoZeroJson, rawHint, pc)
+			hint, err := GetHintFromCode(cairoZeroJson, rawHint, pc, customHints)
 			if err != nil {
 				return nil, err
 			}
@@ -44,13 +52,47 @@ func GetZeroHints(cairoZeroJson *zero.ZeroProgram) (map[uint64][]hinter.Hinter,
 	return hints, nil
 }
 
-func GetHintFromCode(program *zero.ZeroProgram, rawHint zero.Hint, hintPC uint64) (hinter.Hinter, error) {
+func GetHintFromCode(program *zero.ZeroProgram, rawHint zero.Hint, hintPC uint64, customHints CustomHints) (hinter.Hinter, error) {
 	resolver, err := getParameters(program, rawHint, hintPC)
 	if err != nil {
 		return nil, err
 	}
 
-	switch rawHint.Code {
+	hint, err := createHinterFromCode(resolver, rawHint.Code, customHints)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hints declaring their operands fail here, when the program is loaded,
+	// rather than when they are first executed
+	if err := resolver.CheckDeclaredOperands(hint); err != nil {
+		return nil, fmt.Errorf("hint %s: %w", hint, err)
+	}
+	return hint, nil
+}
+
+// ReferenceResolver gives a hint factory access to the references and
+// constants visible from the hint being created
+type ReferenceResolver interface {
+	GetReference(name string) (hinter.Reference, error)
+	GetResOperander(name string) (hinter.ResOperander, error)
+	GetCellRefer(name string) (hinter.CellRefer, error)
+}
+
+// HintFactory creates the hinter of a hint from the references it uses
+type HintFactory func(resolver ReferenceResolver) (hinter.Hinter, error)
+
+// CustomHints maps hint codes to the factories creating their hinters. They are
+// given per program to GetZeroHints. Custom hints take precedence over the built-in
+// ones, so a code that is already supported gets its implementation overridden
+type CustomHints map[string]HintFactory
+
+func createHinterFromCode(resolver hintReferenceResolver, code string, customHints CustomHints) (hinter.Hinter, error) {
+	if factory, ok := customHints[code]; ok {
+		return factory(&resolver)
+	}
+
+	switch code {
 	// Math hints
 	case isLeFeltCode:
 		return createIsLeFeltHinter(resolver)
@@ -96,6 +138,12 @@ func GetHintFromCode(program *zero.ZeroProgram, rawHint zero.Hint, hintPC uint64
 		return createUnsignedDivRemHinter(resolver)
 	case isQuadResidueCode:
 		return createIsQuadResidueHinter(resolver)
+	case isAddrBoundedCode:
+		return createIsAddrBoundedHinter(resolver)
+	case is250BitsCode:
+		return createIs250BitsHinter(resolver)
+	case getFeltBitLengthCode:
+		return createGetFeltBitLengthHinter(resolver)
 	// Uint256 hints
 	case uint256AddCode:
 		return createUint256AddHinter(resolver)
@@ -120,6 +168,8 @@ func GetHintFromCode(program *zero.ZeroProgram, rawHint zero.Hint, hintPC uint64
 		return createImportSecp256R1PHinter()
 	case verifyZeroCode:
 		return createVerifyZeroHinter(resolver)
+	case verifyZeroModCode:
+		return createVerifyZeroModHinter(resolver)
 	case divModNPackedDivmodV1Code:
 		return createDivModNPackedDivmodV1Hinter(resolver)
 	// EC hints
@@ -149,11 +199,17 @@ func GetHintFromCode(program *zero.ZeroProgram, rawHint zero.Hint, hintPC uint64
 		return createIsZeroPackHinter(resolver)
 	case isZeroDivModCode:
 		return createIsZeroDivModHinter()
+	case recoverYCode:
+		return createRecoverYHinter(resolver)
 	// Blake hints
 	case blake2sAddUint256BigendCode:
 		return createBlake2sAddUint256Hinter(resolver, true)
 	case blake2sAddUint256Code:
 		return createBlake2sAddUint256Hinter(resolver, false)
+	case blake2sAddFeltBigendCode:
+		return createBlake2sAddFeltHinter(resolver, true)
+	case blake2sAddFeltCode:
+		return createBlake2sAddFeltHinter(resolver, false)
 	case blake2sFinalizeCode:
 		return createBlake2sFinalizeHinter(resolver)
 	case blake2sComputeCode:
@@ -173,6 +229,16 @@ func GetHintFromCode(program *zero.ZeroProgram, rawHint zero.Hint, hintPC uint64
 		return createBlockPermutationHinter(resolver)
 	case compareBytesInWordCode:
 		return createCompareBytesInWordNondetHinter(resolver)
+	case splitInput3Code:
+		return createSplitInputHinter(resolver, 1)
+	case splitInput6Code:
+		return createSplitInputHinter(resolver, 2)
+	case splitInput9Code:
+		return createSplitInputHinter(resolver, 3)
+	case splitInput12Code:
+		return createSplitInputHinter(resolver, 4)
+	case splitInput15Code:
+		return createSplitInputHinter(resolver, 5)
 	// Usort hints
 	case usortEnterScopeCode:
 		return createUsortEnterScopeHinter()
@@ -244,13 +310,19 @@ func GetHintFromCode(program *zero.ZeroProgram, rawHint zero.Hint, hintPC uint64
 		return createNondetElementsOverTWoHinter(resolver)
 	case nondetElementsOverTenCode:
 		return createNondetElementsOverTenHinter(resolver)
+	case loadProgramCode:
+		return createLoadProgramHinter(resolver)
 	default:
+		if assertEqualCodeRegexp.MatchString(code) {
+			return createAssertEqualHinter(resolver, code)
+		}
 		return nil, fmt.Errorf("not identified hint")
 	}
 }
 
 func getParameters(zeroProgram *zero.ZeroProgram, hint zero.Hint, hintPC uint64) (hintReferenceResolver, error) {
 	resolver := NewReferenceResolver()
+	resolver.SetConstants(zeroProgram.Identifiers, hint.AccessibleScopes)
 
 	for referenceName := range hint.FlowTrackingData.ReferenceIds {
 		rawIdentifier, ok := zeroProgram.Identifiers[referenceName]
@@ -118,6 +118,81 @@ func createBlake2sAddUint256Hinter(resolver hintReferenceResolver, bigend bool)
 	return newBlake2sAddUint256Hint(low, high, data, bigend), nil
 }
 
+// Blake2sAddFelt hint serializes a `felt` in a Blake2s compatible way
+//
+// `newBlake2sAddFeltHint` takes 2 operanders as arguments
+//   - `num` is the `felt` to serialize
+//   - `data` is a pointer to the starting address in memory where to write the result of the hint
+//
+// `newBlake2sAddFeltHint` splits the `felt` in 8 `u32` and writes the result in memory
+// This hint is available in Big-Endian or Little-Endian representation
+func newBlake2sAddFeltHint(num, data hinter.ResOperander, bigend bool) hinter.Hinter {
+	name := "Blake2sAddFelt"
+	if bigend {
+		name += "Bigend"
+	}
+	return &GenericZeroHinter{
+		Name:     name,
+		Operands: []string{"num", "data"},
+		Op: func(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+			//> B = 32
+			//> MASK = 2 ** 32 - 1
+			//
+			//> non-bigend version
+			//> segments.write_arg(ids.data, [(ids.num >> (B * i)) & MASK for i in range(8)])
+			//
+			//> bigend version
+			//> segments.write_arg(ids.data, [(ids.num >> (B * (7 - i))) & MASK for i in range(8)])
+
+			num, err := hinter.ResolveAsFelt(vm, num)
+			if err != nil {
+				return err
+			}
+			dataPtr, err := hinter.ResolveAsAddress(vm, data)
+			if err != nil {
+				return err
+			}
+
+			var numBig big.Int
+			num.BigInt(&numBig)
+
+			const b uint64 = 32
+			mask := new(big.Int).SetUint64(math.MaxUint32)
+
+			for i := uint64(0); i < 8; i++ {
+				shift := uint(b * i)
+				if bigend {
+					shift = uint(b * (7 - i))
+				}
+
+				wordBig := new(big.Int).Rsh(&numBig, shift)
+				wordBig.And(wordBig, mask)
+				mv := mem.MemoryValueFromFieldElement(new(fp.Element).SetBigInt(wordBig))
+				err = vm.Memory.Write(dataPtr.SegmentIndex, dataPtr.Offset+i, &mv)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func createBlake2sAddFeltHinter(resolver hintReferenceResolver, bigend bool) (hinter.Hinter, error) {
+	num, err := resolver.GetResOperander("num")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := resolver.GetResOperander("data")
+	if err != nil {
+		return nil, err
+	}
+
+	return newBlake2sAddFeltHint(num, data, bigend), nil
+}
+
 func newBlake2sFinalizeHint(blake2sPtrEnd hinter.ResOperander) hinter.Hinter {
 	name := "Blake2sFinalize"
 	return &GenericZeroHinter{
@@ -9,6 +9,54 @@ import (
 
 func TestZeroHintBlake(t *testing.T) {
 	runHinterTests(t, map[string][]hintTestCase{
+		"Blake2sAddFelt": {
+			{
+				// 2**250 + 3 * 2**96 + 0x89abcdef
+				operanders: []*hintOperander{
+					{Name: "num", Kind: fpRelative, Value: feltString("1809251394333065553493296640760748560207343510638318300659317762906584239599")},
+					{Name: "data", Kind: apRelative, Value: addr(7)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newBlake2sAddFeltHint(ctx.operanders["num"], ctx.operanders["data"], false)
+				},
+				check: consecutiveVarAddrResolvedValueEquals(
+					"data",
+					[]*fp.Element{
+						feltUint64(2309737967),
+						feltUint64(0),
+						feltUint64(0),
+						feltUint64(3),
+						feltUint64(0),
+						feltUint64(0),
+						feltUint64(0),
+						feltUint64(67108864),
+					}),
+			},
+		},
+		"Blake2sAddFeltBigend": {
+			{
+				// 2**250 + 3 * 2**96 + 0x89abcdef
+				operanders: []*hintOperander{
+					{Name: "num", Kind: fpRelative, Value: feltString("1809251394333065553493296640760748560207343510638318300659317762906584239599")},
+					{Name: "data", Kind: apRelative, Value: addr(7)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newBlake2sAddFeltHint(ctx.operanders["num"], ctx.operanders["data"], true)
+				},
+				check: consecutiveVarAddrResolvedValueEquals(
+					"data",
+					[]*fp.Element{
+						feltUint64(67108864),
+						feltUint64(0),
+						feltUint64(0),
+						feltUint64(0),
+						feltUint64(3),
+						feltUint64(0),
+						feltUint64(0),
+						feltUint64(2309737967),
+					}),
+			},
+		},
 		"Blake2sAddUint256Bigend": {
 			{
 				// 2**256 - 1
@@ -539,6 +587,55 @@ func TestZeroHintBlake(t *testing.T) {
 						feltUint64(2049603206),
 					}),
 			},
+			{
+				// last block of bytes 0..99: h is the state after compressing the first
+				// 64 bytes, t counts all the 100 bytes and f flags the final block
+				operanders: []*hintOperander{
+					{Name: "output", Kind: apRelative, Value: addrWithSegment(1, 31)},
+					{Name: "h.1", Kind: apRelative, Value: feltUint64(1773694684)},
+					{Name: "h.2", Kind: apRelative, Value: feltUint64(2624895861)},
+					{Name: "h.3", Kind: apRelative, Value: feltUint64(633147438)},
+					{Name: "h.4", Kind: apRelative, Value: feltUint64(2213691433)},
+					{Name: "h.5", Kind: apRelative, Value: feltUint64(2593993409)},
+					{Name: "h.6", Kind: apRelative, Value: feltUint64(2686603451)},
+					{Name: "h.7", Kind: apRelative, Value: feltUint64(983607156)},
+					{Name: "h.8", Kind: apRelative, Value: feltUint64(3837345309)},
+					{Name: "message.1", Kind: apRelative, Value: feltUint64(1128415552)},
+					{Name: "message.2", Kind: apRelative, Value: feltUint64(1195787588)},
+					{Name: "message.3", Kind: apRelative, Value: feltUint64(1263159624)},
+					{Name: "message.4", Kind: apRelative, Value: feltUint64(1330531660)},
+					{Name: "message.5", Kind: apRelative, Value: feltUint64(1397903696)},
+					{Name: "message.6", Kind: apRelative, Value: feltUint64(1465275732)},
+					{Name: "message.7", Kind: apRelative, Value: feltUint64(1532647768)},
+					{Name: "message.8", Kind: apRelative, Value: feltUint64(1600019804)},
+					{Name: "message.9", Kind: apRelative, Value: feltUint64(1667391840)},
+					{Name: "message.10", Kind: apRelative, Value: feltUint64(0)},
+					{Name: "message.11", Kind: apRelative, Value: feltUint64(0)},
+					{Name: "message.12", Kind: apRelative, Value: feltUint64(0)},
+					{Name: "message.13", Kind: apRelative, Value: feltUint64(0)},
+					{Name: "message.14", Kind: apRelative, Value: feltUint64(0)},
+					{Name: "message.15", Kind: apRelative, Value: feltUint64(0)},
+					{Name: "message.16", Kind: apRelative, Value: feltUint64(0)},
+					{Name: "t", Kind: apRelative, Value: feltUint64(100)},
+					{Name: "f", Kind: apRelative, Value: feltUint64(0xffffffff)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newBlake2sComputeHint(ctx.operanders["output"])
+				},
+				// blake2s-256 digest: 81dcc3a505eace3f879d8f702776770f9df50e521d1428a85daf04f9ad2150e0
+				check: consecutiveVarAddrResolvedValueEquals(
+					"output",
+					[]*fp.Element{
+						feltUint64(2781076609),
+						feltUint64(1070524933),
+						feltUint64(1888460167),
+						feltUint64(259487271),
+						feltUint64(1376712093),
+						feltUint64(2821198877),
+						feltUint64(4177833821),
+						feltUint64(3763347885),
+					}),
+			},
 		},
 	})
 }
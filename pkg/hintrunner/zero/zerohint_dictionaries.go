@@ -48,7 +48,9 @@ func newDictNewHint() hinter.Hinter {
 				}
 			}
 
-			initialDictValue, err := ctx.ScopeManager.GetVariableValue("initial_dict")
+			// initial_dict is either set by a previous hint or seeded in the root scope
+			// by the runner program input
+			initialDictValue, err := ctx.ScopeManager.GetVariableValueFromAnyScope("initial_dict")
 			if err != nil {
 				return err
 			}
@@ -67,7 +69,7 @@ func newDictNewHint() hinter.Hinter {
 			}
 
 			//> del initial_dict
-			return ctx.ScopeManager.DeleteVariable("initial_dict")
+			return ctx.ScopeManager.DeleteVariableFromAnyScope("initial_dict")
 		},
 	}
 }
@@ -111,7 +113,24 @@ func newDefaultDictNewHint(defaultValue hinter.ResOperander) hinter.Hinter {
 			}
 
 			defaultValueMv := memory.MemoryValueFromFieldElement(defaultValue)
-			newDefaultDictionaryAddr := dictionaryManager.NewDefaultDictionary(vm, defaultValueMv)
+			var newDefaultDictionaryAddr memory.MemoryAddress
+
+			// The runner program input can seed the first default dictionary, the same
+			// way `initial_dict` seeds the first `dict_new` one. The seed lives in the root
+			// scope, while the hint usually runs in a scope entered by the library code
+			initialDefaultDict, err := ctx.ScopeManager.GetVariableValueFromAnyScope("__initial_default_dict")
+			if err == nil {
+				initialData, ok := initialDefaultDict.(map[fp.Element]memory.MemoryValue)
+				if !ok {
+					return fmt.Errorf("__initial_default_dict is a %T, expected a map[fp.Element]memory.MemoryValue", initialDefaultDict)
+				}
+				newDefaultDictionaryAddr = dictionaryManager.NewDefaultDictionaryWithData(vm, defaultValueMv, initialData)
+				if err := ctx.ScopeManager.DeleteVariableFromAnyScope("__initial_default_dict"); err != nil {
+					return err
+				}
+			} else {
+				newDefaultDictionaryAddr = dictionaryManager.NewDefaultDictionary(vm, defaultValueMv)
+			}
 			newDefaultDictionaryAddrMv := memory.MemoryValueFromMemoryAddress(&newDefaultDictionaryAddr)
 			apAddr := vm.Context.AddressAp()
 
@@ -173,7 +192,7 @@ func newDictReadHint(dictPtr, key, value hinter.ResOperander) hinter.Hinter {
 			}
 
 			//> dict_tracker.current_ptr += ids.DictAccess.SIZE
-			return dictionaryManager.IncrementFreeOffset(*dictPtr, DictAccessSize)
+			return dictionaryManager.AppendAccess(vm, *dictPtr, *key, keyValue, keyValue)
 		},
 	}
 }
@@ -229,23 +248,7 @@ func newDictSquashCopyDictHint(dictAccessesEnd hinter.ResOperander) hinter.Hinte
 				return err
 			}
 
-			dictionaryDataCopy := make(map[fp.Element]memory.MemoryValue)
-			for k, v := range *dictionary.Data {
-				// Copy the key
-				keyCopy := fp.Element{}
-				keyCopy.Set(&k)
-
-				// Copy the value
-				feltCopy := fp.Element{}
-				feltCopy.Set(&v.Felt)
-
-				valueCopy := memory.MemoryValue{
-					Felt: feltCopy,
-					Kind: v.Kind,
-				}
-
-				dictionaryDataCopy[keyCopy] = valueCopy
-			}
+			dictionaryDataCopy := copyDictionaryData(*dictionary.Data)
 
 			ctx.ScopeManager.EnterScope(map[string]any{"__dict_manager": dictionaryManager, "initial_dict": dictionaryDataCopy})
 
@@ -263,6 +266,30 @@ func createDictSquashCopyDictHinter(resolver hintReferenceResolver) (hinter.Hint
 	return newDictSquashCopyDictHint(dictAccessesEnd), nil
 }
 
+// copyDictionaryData copies the data of a dictionary, so that the copy doesn't
+// change along with the dictionary
+func copyDictionaryData(data map[fp.Element]memory.MemoryValue) map[fp.Element]memory.MemoryValue {
+	dictionaryDataCopy := make(map[fp.Element]memory.MemoryValue)
+	for k, v := range data {
+		// Copy the key
+		keyCopy := fp.Element{}
+		keyCopy.Set(&k)
+
+		// Copy the value. Addresses share the underlying felt storage,
+		// so copying both the felt and the kind preserves pointer values
+		feltCopy := fp.Element{}
+		feltCopy.Set(&v.Felt)
+
+		valueCopy := memory.MemoryValue{
+			Felt: feltCopy,
+			Kind: v.Kind,
+		}
+
+		dictionaryDataCopy[keyCopy] = valueCopy
+	}
+	return dictionaryDataCopy
+}
+
 // DictWrite hint writes a value for a given key in a dictionary
 // and writes to memory the previous value for the key in the dictionary
 //
@@ -305,10 +332,6 @@ func newDictWriteHint(dictPtr, key, newValue hinter.ResOperander) hinter.Hinter
 			if err != nil {
 				return err
 			}
-			err = vm.Memory.WriteToNthStructField(*dictPtr, prevKeyValue, 1)
-			if err != nil {
-				return err
-			}
 
 			//> dict_tracker.data[ids.key] = ids.new_value
 			newValue, err := hinter.ResolveAsFelt(vm, newValue)
@@ -322,7 +345,7 @@ func newDictWriteHint(dictPtr, key, newValue hinter.ResOperander) hinter.Hinter
 			}
 
 			//> dict_tracker.current_ptr += ids.DictAccess.SIZE
-			return dictionaryManager.IncrementFreeOffset(*dictPtr, DictAccessSize)
+			return dictionaryManager.AppendAccess(vm, *dictPtr, *key, prevKeyValue, newValueMv)
 		},
 	}
 }
@@ -414,7 +437,7 @@ func newDictUpdateHint(dictPtr, key, newValue, prevValue hinter.ResOperander) hi
 			}
 
 			//> dict_tracker.current_ptr += ids.DictAccess.SIZE
-			return dictionaryManager.IncrementFreeOffset(*dictPtr, DictAccessSize)
+			return dictionaryManager.AppendAccess(vm, *dictPtr, *key, currentValueMv, newValueMv)
 		},
 	}
 }
@@ -527,6 +550,22 @@ func newSquashDictHint(dictAccesses, ptrDiff, nAccesses, bigKeys, firstKey hinte
 				accessIndices[key] = append(accessIndices[key], fp.NewElement(i))
 			}
 
+			// __squash_dict_max_key_accesses is an optional limit on the number of
+			// accesses to a single key, guarding against adversarial dictionaries.
+			// The number of accesses per key is unlimited when it is not in scope
+			maxKeyAccesses, err := ctx.ScopeManager.GetVariableValue("__squash_dict_max_key_accesses")
+			if err == nil {
+				maxKeyAccesses, ok := maxKeyAccesses.(uint64)
+				if !ok {
+					return fmt.Errorf("invalid value for __squash_dict_max_key_accesses. Got: %v", maxKeyAccesses)
+				}
+				for key, indices := range accessIndices {
+					if uint64(len(indices)) > maxKeyAccesses {
+						return fmt.Errorf("squash_dict() can only be used with at most %d accesses per key. Got: %d accesses to key %v", maxKeyAccesses, len(indices), &key)
+					}
+				}
+			}
+
 			//> # Descending list of keys.
 			//> keys = sorted(access_indices.keys(), reverse=True)
 			keys := maps.Keys(accessIndices)
@@ -932,7 +971,10 @@ func newSquashDictInnerLenAssertHint() hinter.Hinter {
 				return err
 			}
 
-			currentAccessIndices := currentAccessIndices_.([]fp.Element)
+			currentAccessIndices, ok := currentAccessIndices_.([]fp.Element)
+			if !ok {
+				return fmt.Errorf("cannot cast current_access_indices_ to []fp.Element")
+			}
 			if len(currentAccessIndices) != 0 {
 				return fmt.Errorf("assertion `len(current_access_indices) == 0` failed")
 			}
@@ -1043,7 +1085,11 @@ func newSquashDictInnerUsedAccessesAssertHint(nUsedAccesses hinter.ResOperander)
 			}
 
 			if accessIndicesAtKeyLen != nUsedAccesses {
-				return fmt.Errorf("assertion ids.n_used_accesses == len(access_indices[key]) failed")
+				return fmt.Errorf(
+					"assertion ids.n_used_accesses == len(access_indices[key]) failed: Number of used accesses doesn't match (%d != %d)",
+					nUsedAccesses,
+					accessIndicesAtKeyLen,
+				)
 			}
 
 			return nil
@@ -1085,7 +1131,12 @@ func newDictSquashUpdatePtrHint(squashedDictStart, squashedDictEnd hinter.ResOpe
 				return fmt.Errorf("__dict_manager not in scope")
 			}
 
-			// TODO: figure out if its ever possible for squashedDictEnd segment to be different from the dictionary segment
+			// The squashed dictionary is written in its own segment by squash_dict, so both
+			// pointers always share the segment the dictionary is tracked by
+			if squashedDictEnd.SegmentIndex != squashedDictStart.SegmentIndex {
+				return fmt.Errorf("squashed dict end %s is not in the segment of squashed dict start %s", squashedDictEnd, squashedDictStart)
+			}
+
 			return dictionaryManager.SetFreeOffset(*squashedDictStart, squashedDictEnd.Offset)
 		},
 	}
@@ -7,6 +7,8 @@ import (
 	"github.com/NethermindEth/cairo-vm-go/pkg/utils"
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
 )
 
 func TestZeroHintDictionaries(t *testing.T) {
@@ -106,6 +108,117 @@ func TestZeroHintDictionaries(t *testing.T) {
 					}
 				},
 			},
+			{
+				// the seed is read from the root scope, inside a scope entered by the library
+				operanders: []*hintOperander{
+					{Name: "default_value", Kind: apRelative, Value: feltUint64(12345)},
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("__initial_default_dict", map[fp.Element]memory.MemoryValue{
+						*feltUint64(10): memory.MemoryValueFromUint(uint(1000)),
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+					ctx.ScopeManager.EnterScope(map[string]any{})
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newDefaultDictNewHint(ctx.operanders["default_value"])
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					_, err := ctx.runnerContext.ScopeManager.GetVariableValueFromAnyScope("__initial_default_dict")
+					if err == nil {
+						t.Fatalf("__initial_default_dict not deleted")
+					}
+					dictionaryManager, ok := ctx.runnerContext.ScopeManager.GetZeroDictionaryManager()
+					if !ok {
+						t.Fatalf("__dict_manager missing")
+					}
+					apAddr := ctx.vm.Context.AddressAp()
+					dictAddr, err := ctx.vm.Memory.ReadFromAddressAsAddress(&apAddr)
+					if err != nil {
+						t.Fatalf("error reading dictionary address from ap")
+					}
+					value, err := dictionaryManager.At(dictAddr, *feltUint64(10))
+					if err != nil {
+						t.Fatal(err)
+					}
+					expected := memory.MemoryValueFromUint(uint(1000))
+					if !value.Equal(&expected) {
+						t.Fatalf("at key 10 expected: %s actual: %s", &expected, &value)
+					}
+				},
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "default_value", Kind: apRelative, Value: feltUint64(12345)},
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("__initial_default_dict", map[fp.Element]fp.Element{})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newDefaultDictNewHint(ctx.operanders["default_value"])
+				},
+				errCheck: errorTextContains("__initial_default_dict is a map[fp.Element]fp.Element, expected a map[fp.Element]memory.MemoryValue"),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "default_value", Kind: apRelative, Value: feltUint64(12345)},
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("__initial_default_dict", map[fp.Element]memory.MemoryValue{
+						*feltUint64(10): memory.MemoryValueFromUint(uint(1000)),
+						*feltUint64(20): memory.MemoryValueFromUint(uint(2000)),
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newDefaultDictNewHint(ctx.operanders["default_value"])
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					_, err := ctx.runnerContext.ScopeManager.GetVariableValue("__initial_default_dict")
+					if err == nil {
+						t.Fatalf("__initial_default_dict not deleted")
+					}
+
+					apAddr := ctx.vm.Context.AddressAp()
+					dictAddr, err := ctx.vm.Memory.ReadFromAddressAsAddress(&apAddr)
+					if err != nil {
+						t.Fatalf("error reading dictionary address from ap")
+					}
+
+					dictionaryManager, ok := ctx.runnerContext.ScopeManager.GetZeroDictionaryManager()
+					if !ok {
+						t.Fatalf("__dict_manager missing")
+					}
+
+					expectedValues := map[fp.Element]*fp.Element{
+						// initial keys return their values
+						*feltUint64(10): feltUint64(1000),
+						*feltUint64(20): feltUint64(2000),
+						// missing keys return the default value
+						*feltUint64(30): feltUint64(12345),
+					}
+					for key, expectedValueFelt := range expectedValues {
+						value, err := dictionaryManager.At(dictAddr, key)
+						if err != nil {
+							t.Fatalf("error fetching value for key: %v", key)
+						}
+						valueFelt, err := value.FieldElement()
+						if err != nil {
+							t.Fatalf("mv: %s cannot be converted to felt", value)
+						}
+						if !valueFelt.Equal(expectedValueFelt) {
+							t.Fatalf("at key: %v expected: %s actual: %s", key, expectedValueFelt, valueFelt)
+						}
+					}
+				},
+			},
 		},
 		"DictRead": {
 			{
@@ -458,6 +571,19 @@ func TestZeroHintDictionaries(t *testing.T) {
 				},
 				errCheck: errorTextContains("assertion `len(current_access_indices) == 0` failed"),
 			},
+			{
+				operanders: []*hintOperander{},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("current_access_indices", map[fp.Element][]fp.Element{})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSquashDictInnerLenAssertHint()
+				},
+				errCheck: errorTextContains("cannot cast current_access_indices_ to []fp.Element"),
+			},
 		},
 		"SquashDictInnerNextKey": {
 			{
@@ -541,7 +667,7 @@ func TestZeroHintDictionaries(t *testing.T) {
 				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
 					return newSquashDictInnerUsedAccessesAssertHint(ctx.operanders["n_used_accesses"])
 				},
-				errCheck: errorTextContains("assertion ids.n_used_accesses == len(access_indices[key]) failed"),
+				errCheck: errorTextContains("assertion ids.n_used_accesses == len(access_indices[key]) failed: Number of used accesses doesn't match (0 != 3)"),
 			},
 			{
 				operanders: []*hintOperander{
@@ -571,7 +697,7 @@ func TestZeroHintDictionaries(t *testing.T) {
 				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
 					return newSquashDictInnerUsedAccessesAssertHint(ctx.operanders["n_used_accesses"])
 				},
-				errCheck: errorTextContains("assertion ids.n_used_accesses == len(access_indices[key]) failed"),
+				errCheck: errorTextContains("assertion ids.n_used_accesses == len(access_indices[key]) failed: Number of used accesses doesn't match (3 != 0)"),
 			},
 		},
 		"SquashDict": {
@@ -627,6 +753,78 @@ func TestZeroHintDictionaries(t *testing.T) {
 				},
 				errCheck: errorTextContains("squash_dict() can only be used with n_accesses<={1048576}. Got: n_accesses={1048577}"),
 			},
+			{
+				operanders: []*hintOperander{
+					{Name: "dict_accesses.1.key", Kind: apRelative, Value: feltUint64(3)},
+					{Name: "dict_accesses.1.prev_value", Kind: apRelative, Value: feltUint64(0)},
+					{Name: "dict_accesses.1.new_value", Kind: apRelative, Value: feltUint64(1)},
+					{Name: "dict_accesses.2.key", Kind: apRelative, Value: feltUint64(5)},
+					{Name: "dict_accesses.2.prev_value", Kind: apRelative, Value: feltUint64(0)},
+					{Name: "dict_accesses.2.new_value", Kind: apRelative, Value: feltUint64(1)},
+					{Name: "dict_accesses.3.key", Kind: apRelative, Value: feltUint64(3)},
+					{Name: "dict_accesses.3.prev_value", Kind: apRelative, Value: feltUint64(1)},
+					{Name: "dict_accesses.3.new_value", Kind: apRelative, Value: feltUint64(2)},
+					{Name: "ptr_diff", Kind: apRelative, Value: feltUint64(9)},
+					{Name: "n_accesses", Kind: apRelative, Value: feltUint64(3)},
+					{Name: "big_keys", Kind: uninitialized},
+					{Name: "first_key", Kind: uninitialized},
+					{Name: "dict_accesses", Kind: apRelative, Value: addrWithSegment(1, 4)},
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("__squash_dict_max_key_accesses", uint64(1))
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSquashDictHint(
+						ctx.operanders["dict_accesses"],
+						ctx.operanders["ptr_diff"],
+						ctx.operanders["n_accesses"],
+						ctx.operanders["big_keys"],
+						ctx.operanders["first_key"],
+					)
+				},
+				// key 3 is accessed twice
+				errCheck: errorTextContains("squash_dict() can only be used with at most 1 accesses per key. Got: 2 accesses to key 3"),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "dict_accesses.1.key", Kind: apRelative, Value: feltUint64(3)},
+					{Name: "dict_accesses.1.prev_value", Kind: apRelative, Value: feltUint64(0)},
+					{Name: "dict_accesses.1.new_value", Kind: apRelative, Value: feltUint64(1)},
+					{Name: "dict_accesses.2.key", Kind: apRelative, Value: feltUint64(5)},
+					{Name: "dict_accesses.2.prev_value", Kind: apRelative, Value: feltUint64(0)},
+					{Name: "dict_accesses.2.new_value", Kind: apRelative, Value: feltUint64(1)},
+					{Name: "dict_accesses.3.key", Kind: apRelative, Value: feltUint64(3)},
+					{Name: "dict_accesses.3.prev_value", Kind: apRelative, Value: feltUint64(1)},
+					{Name: "dict_accesses.3.new_value", Kind: apRelative, Value: feltUint64(2)},
+					{Name: "ptr_diff", Kind: apRelative, Value: feltUint64(9)},
+					{Name: "n_accesses", Kind: apRelative, Value: feltUint64(3)},
+					{Name: "big_keys", Kind: uninitialized},
+					{Name: "first_key", Kind: uninitialized},
+					{Name: "dict_accesses", Kind: apRelative, Value: addrWithSegment(1, 4)},
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("__squash_dict_max_key_accesses", uint64(2))
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSquashDictHint(
+						ctx.operanders["dict_accesses"],
+						ctx.operanders["ptr_diff"],
+						ctx.operanders["n_accesses"],
+						ctx.operanders["big_keys"],
+						ctx.operanders["first_key"],
+					)
+				},
+				check: allVarValueEquals(map[string]*fp.Element{
+					"big_keys":  feltInt64(0),
+					"first_key": feltInt64(3),
+				}),
+			},
 			{
 				operanders: []*hintOperander{
 					// random correct values
@@ -811,6 +1009,106 @@ func TestZeroHintDictionaries(t *testing.T) {
 					zeroDictInScopeEquals(*dictPtr, expectedData, expectedDefaultValue, expectedFreeOffset)(t, ctx)
 				},
 			},
+			{
+				// dict_squash: the dictionary in segment 2 has 3 accesses, it is squashed
+				// into a new dictionary in segment 3 holding 2 accesses
+				operanders: []*hintOperander{
+					{Name: "squashed_dict_start", Kind: apRelative, Value: addrWithSegment(3, 0)},
+					{Name: "squashed_dict_end", Kind: apRelative, Value: addrWithSegment(3, 6)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					dictionaryManager := hinter.NewZeroDictionaryManager()
+					err := ctx.runnerContext.ScopeManager.AssignVariable("__dict_manager", dictionaryManager)
+					if err != nil {
+						t.Fatal(err)
+					}
+					data := map[fp.Element]memory.MemoryValue{
+						*feltUint64(1): memory.MemoryValueFromInt(10),
+						*feltUint64(2): memory.MemoryValueFromInt(20),
+					}
+					dictPtr := dictionaryManager.NewDictionary(ctx.vm, data)
+					if err := dictionaryManager.IncrementFreeOffset(dictPtr, 9); err != nil {
+						t.Fatal(err)
+					}
+					dictionaryManager.NewDictionary(ctx.vm, maps.Clone(data))
+
+					return newDictSquashUpdatePtrHint(
+						ctx.operanders["squashed_dict_start"],
+						ctx.operanders["squashed_dict_end"],
+					)
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					expectedData := map[fp.Element]memory.MemoryValue{
+						*feltUint64(1): memory.MemoryValueFromInt(10),
+						*feltUint64(2): memory.MemoryValueFromInt(20),
+					}
+					// The squashed dictionary is now tracked at its end
+					zeroDictInScopeEquals(*addrWithSegment(3, 6), expectedData, memory.UnknownValue, 6)(t, ctx)
+					// The original dictionary is left untouched
+					zeroDictInScopeEquals(*addrWithSegment(2, 9), expectedData, memory.UnknownValue, 9)(t, ctx)
+				},
+			},
+			{
+				// squashed_dict_start doesn't have to be at the start of the segment, the
+				// tracker is looked up by segment and moved to squashed_dict_end as is
+				operanders: []*hintOperander{
+					{Name: "squashed_dict_start", Kind: apRelative, Value: addrWithSegment(2, 4)},
+					{Name: "squashed_dict_end", Kind: apRelative, Value: addrWithSegment(2, 10)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					dictionaryManager := hinter.NewZeroDictionaryManager()
+					err := ctx.runnerContext.ScopeManager.AssignVariable("__dict_manager", dictionaryManager)
+					if err != nil {
+						t.Fatal(err)
+					}
+					dictPtr := dictionaryManager.NewDictionary(ctx.vm, map[fp.Element]memory.MemoryValue{})
+					if err := dictionaryManager.IncrementFreeOffset(dictPtr, 4); err != nil {
+						t.Fatal(err)
+					}
+
+					return newDictSquashUpdatePtrHint(
+						ctx.operanders["squashed_dict_start"],
+						ctx.operanders["squashed_dict_end"],
+					)
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					// The free offset is the offset of squashed_dict_end, not its distance to squashed_dict_start
+					zeroDictInScopeEquals(*addrWithSegment(2, 10), map[fp.Element]memory.MemoryValue{}, memory.UnknownValue, 10)(t, ctx)
+				},
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "squashed_dict_start", Kind: apRelative, Value: addrWithSegment(2, 0)},
+					{Name: "squashed_dict_end", Kind: apRelative, Value: addrWithSegment(3, 6)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					dictionaryManager := hinter.NewZeroDictionaryManager()
+					err := ctx.runnerContext.ScopeManager.AssignVariable("__dict_manager", dictionaryManager)
+					if err != nil {
+						t.Fatal(err)
+					}
+					dictionaryManager.NewDictionary(ctx.vm, map[fp.Element]memory.MemoryValue{})
+
+					return newDictSquashUpdatePtrHint(
+						ctx.operanders["squashed_dict_start"],
+						ctx.operanders["squashed_dict_end"],
+					)
+				},
+				errCheck: errorTextContains("squashed dict end 3:6 is not in the segment of squashed dict start 2:0"),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "squashed_dict_start", Kind: apRelative, Value: addrWithSegment(2, 0)},
+					{Name: "squashed_dict_end", Kind: apRelative, Value: addrWithSegment(2, 6)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newDictSquashUpdatePtrHint(
+						ctx.operanders["squashed_dict_start"],
+						ctx.operanders["squashed_dict_end"],
+					)
+				},
+				errCheck: errorTextContains("__dict_manager not in scope"),
+			},
 		},
 		"DictSquashCopyDict": {
 			{
@@ -855,6 +1153,44 @@ func TestZeroHintDictionaries(t *testing.T) {
 				},
 				check: varValueInScopeEquals("initial_dict", make(map[fp.Element]memory.MemoryValue)),
 			},
+			{
+				operanders: []*hintOperander{
+					{Name: "dict_accesses_end", Kind: apRelative, Value: addrWithSegment(2, 0)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					dictionaryManager := hinter.NewZeroDictionaryManager()
+					dictionaryManager.NewDictionary(ctx.vm, map[fp.Element]memory.MemoryValue{
+						*feltUint64(1): memory.MemoryValueFromSegmentAndOffset(3, 0),
+						*feltUint64(2): memory.MemoryValueFromSegmentAndOffset(3, 4),
+					})
+					// segment 3 holds the values pointed to by the dictionary
+					ctx.vm.Memory.AllocateEmptySegment()
+
+					err := ctx.runnerContext.ScopeManager.AssignVariable("__dict_manager", dictionaryManager)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					return newDictSquashCopyDictHint(ctx.operanders["dict_accesses_end"])
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					varValueInScopeEquals("initial_dict", map[fp.Element]memory.MemoryValue{
+						*feltUint64(1): memory.MemoryValueFromSegmentAndOffset(3, 0),
+						*feltUint64(2): memory.MemoryValueFromSegmentAndOffset(3, 4),
+					})(t, ctx)
+
+					initialDict, err := ctx.runnerContext.ScopeManager.GetVariableValue("initial_dict")
+					require.NoError(t, err)
+
+					segmentsOffsets, _ := ctx.vm.Memory.RelocationOffsets()
+					for key, expectedOffset := range map[fp.Element]uint64{*feltUint64(1): 0, *feltUint64(2): 4} {
+						value := initialDict.(map[fp.Element]memory.MemoryValue)[key]
+						address, err := value.MemoryAddress()
+						require.NoError(t, err)
+						require.Equal(t, feltUint64(segmentsOffsets[3]+expectedOffset), address.Relocate(segmentsOffsets))
+					}
+				},
+			},
 		},
 	})
 }
@@ -141,7 +141,7 @@ func createNondetBigint3V1Hinter(resolver hintReferenceResolver) (hinter.Hinter,
 //   - `point0` and `point1` are 2 points on an elliptic curve
 //
 // `newFastEcAddAssignNewXHint` assigns the new x-coordinate as `value` in the current scope
-// It also assigns `slope`, `x0`, `y0` and `new_x` in the current scope
+// It also assigns `slope`, `x0`, `y0`, `new_x` and `SECP_P` in the current scope
 // so that they are available in the current scope for FastEcAddAssignNewY hint
 func newFastEcAddAssignNewXHint(slope, point0, point1 hinter.ResOperander) hinter.Hinter {
 	return &GenericZeroHinter{
@@ -236,7 +236,7 @@ func newFastEcAddAssignNewXHint(slope, point0, point1 hinter.ResOperander) hinte
 			valueBig := new(big.Int)
 			valueBig.Set(new_xBig)
 
-			return ctx.ScopeManager.AssignVariables(map[string]any{"slope": &slopeBig, "x0": &x0Big, "y0": &y0Big, "new_x": new_xBig, "value": valueBig})
+			return ctx.ScopeManager.AssignVariables(map[string]any{"slope": &slopeBig, "x0": &x0Big, "y0": &y0Big, "new_x": new_xBig, "value": valueBig, "SECP_P": &secPBig})
 		},
 	}
 }
@@ -381,7 +381,7 @@ func createEcDoubleSlopeV1Hinter(resolver hintReferenceResolver) (hinter.Hinter,
 	return newEcDoubleSlopeV1Hint(point), nil
 }
 
-// ReduceV1 hint reduces a packed value modulo the SECP256R1 prime
+// ReduceV1 hint reduces a packed value modulo the secp256k1 prime
 //
 // `newReduceV1Hint` takes 1 operander as argument
 //   - `x` is the packed value to be reduced
@@ -701,6 +701,13 @@ func createComputeSlopeV1Hinter(resolver hintReferenceResolver) (hinter.Hinter,
 	return newComputeSlopeV1Hint(point0, point1), nil
 }
 
+// EcMulInner hint computes the parity of the scalar in the `ec_mul_inner` Cairo function,
+// which decides whether the current point has to be added to the result
+//
+// `newEcMulInnerHint` takes 1 operander as argument
+//   - `scalar` is the remaining scalar to multiply the point with
+//
+// `newEcMulInnerHint` writes to `[ap]` the least significant bit of `scalar`
 func newEcMulInnerHint(scalar hinter.ResOperander) hinter.Hinter {
 	return &GenericZeroHinter{
 		Name: "EcMulInner",
@@ -861,3 +868,75 @@ func newIsZeroDivModHint() hinter.Hinter {
 func createIsZeroDivModHinter() (hinter.Hinter, error) {
 	return newIsZeroDivModHint(), nil
 }
+
+// RecoverY hint recovers the y coordinate of a point on the STARK curve from its
+// x coordinate, as `recover_y` of the `ec` library does
+//
+// `newRecoverYHint` takes 2 operanders as arguments
+//   - `x` is the x coordinate of the point
+//   - `p` is the EcPoint variable that will store the point
+//
+// `newRecoverYHint` writes the smallest of the two possible y coordinates, and
+// errors if `x` isn't the x coordinate of a point on the curve
+func newRecoverYHint(x, p hinter.ResOperander) hinter.Hinter {
+	return &GenericZeroHinter{
+		Name:     "RecoverY",
+		Operands: []string{"x", "p"},
+		Op: func(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+			//> from starkware.crypto.signature.signature import ALPHA, BETA, FIELD_PRIME
+			//> from starkware.python.math_utils import recover_y
+			//> ids.p.x = ids.x
+			//> # This raises an exception if `x` is not on the curve.
+			//> ids.p.y = recover_y(ids.x, ALPHA, BETA, FIELD_PRIME)
+
+			xFelt, err := hinter.ResolveAsFelt(vm, x)
+			if err != nil {
+				return err
+			}
+
+			pAddr, err := p.GetAddress(vm)
+			if err != nil {
+				return err
+			}
+
+			// y^2 = x^3 + ALPHA * x + BETA
+			var ySquared, alphaX fp.Element
+			ySquared.Square(xFelt)
+			ySquared.Mul(&ySquared, xFelt)
+			alphaX.Mul(&utils.Alpha, xFelt)
+			ySquared.Add(&ySquared, &alphaX)
+			ySquared.Add(&ySquared, &utils.Beta)
+
+			y := new(fp.Element).Sqrt(&ySquared)
+			if y == nil {
+				return fmt.Errorf("%v does not represent the x coordinate of a point on the curve", xFelt)
+			}
+			// recover_y returns the smallest square root
+			negY := new(fp.Element).Neg(y)
+			if utils.FeltLt(negY, y) {
+				y = negY
+			}
+
+			xMv := mem.MemoryValueFromFieldElement(xFelt)
+			if err := vm.Memory.WriteToNthStructField(pAddr, xMv, 0); err != nil {
+				return err
+			}
+			yMv := mem.MemoryValueFromFieldElement(y)
+			return vm.Memory.WriteToNthStructField(pAddr, yMv, 1)
+		},
+	}
+}
+
+func createRecoverYHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
+	x, err := resolver.GetResOperander("x")
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := resolver.GetResOperander("p")
+	if err != nil {
+		return nil, err
+	}
+
+	return newRecoverYHint(x, p), nil
+}
@@ -7,7 +7,10 @@ import (
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/hinter"
 	secp_utils "github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/utils"
 	"github.com/NethermindEth/cairo-vm-go/pkg/utils"
+	VM "github.com/NethermindEth/cairo-vm-go/pkg/vm"
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/require"
 )
 
 func TestZeroHintEc(t *testing.T) {
@@ -369,11 +372,12 @@ func TestZeroHintEc(t *testing.T) {
 					return newFastEcAddAssignNewXHint(ctx.operanders["slope.d0"], ctx.operanders["point0.x.d0"], ctx.operanders["point1.x.d0"])
 				},
 				check: allVarValueInScopeEquals(map[string]any{
-					"slope": bigIntString("99065496658741969395000079476826955370154683653966841736214499259699304892273", 10),
-					"x0":    bigIntString("3799719333936312867907730225219317480871818784521830610814991", 10),
-					"y0":    bigIntString("5395443952678709065478416501711989224759665054189740766553850", 10),
-					"value": bigIntString("53863685200989733811273896838983614723181733288322685009664997422229669431265", 10),
-					"new_x": bigIntString("53863685200989733811273896838983614723181733288322685009664997422229669431265", 10),
+					"slope":  bigIntString("99065496658741969395000079476826955370154683653966841736214499259699304892273", 10),
+					"x0":     bigIntString("3799719333936312867907730225219317480871818784521830610814991", 10),
+					"y0":     bigIntString("5395443952678709065478416501711989224759665054189740766553850", 10),
+					"value":  bigIntString("53863685200989733811273896838983614723181733288322685009664997422229669431265", 10),
+					"new_x":  bigIntString("53863685200989733811273896838983614723181733288322685009664997422229669431265", 10),
+					"SECP_P": bigIntString("115792089237316195423570985008687907853269984665640564039457584007908834671663", 10),
 				}),
 			},
 			{
@@ -729,6 +733,27 @@ func TestZeroHintEc(t *testing.T) {
 				},
 				errCheck: errorTextContains("the slope of the line is invalid"),
 			},
+			{
+				operanders: []*hintOperander{
+					// same x-coordinate, different y-coordinates
+					{Name: "point0.x.d0", Kind: apRelative, Value: feltInt64(134)},
+					{Name: "point0.x.d1", Kind: apRelative, Value: feltInt64(5123)},
+					{Name: "point0.x.d2", Kind: apRelative, Value: feltInt64(140)},
+					{Name: "point0.y.d0", Kind: apRelative, Value: feltInt64(1232)},
+					{Name: "point0.y.d1", Kind: apRelative, Value: feltInt64(4652)},
+					{Name: "point0.y.d2", Kind: apRelative, Value: feltInt64(720)},
+					{Name: "point1.x.d0", Kind: apRelative, Value: feltInt64(134)},
+					{Name: "point1.x.d1", Kind: apRelative, Value: feltInt64(5123)},
+					{Name: "point1.x.d2", Kind: apRelative, Value: feltInt64(140)},
+					{Name: "point1.y.d0", Kind: apRelative, Value: feltInt64(1123)},
+					{Name: "point1.y.d1", Kind: apRelative, Value: feltInt64(1325)},
+					{Name: "point1.y.d2", Kind: apRelative, Value: feltInt64(910)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newComputeSlopeV1Hint(ctx.operanders["point0.x.d0"], ctx.operanders["point1.x.d0"])
+				},
+				errCheck: errorTextContains("the slope of the line is invalid"),
+			},
 			{
 				operanders: []*hintOperander{
 					// random values
@@ -825,6 +850,30 @@ func TestZeroHintEc(t *testing.T) {
 				},
 				check: varValueInScopeEquals("value", bigIntString("17958932119522135058886879379160190656204633450479616", 10)),
 			},
+			{
+				operanders: []*hintOperander{
+					// values are the 3 results of split(SEC_P)
+					{Name: "x.d0", Kind: apRelative, Value: feltString("77371252455336262886226991")},
+					{Name: "x.d1", Kind: apRelative, Value: feltString("77371252455336267181195263")},
+					{Name: "x.d2", Kind: apRelative, Value: feltString("19342813113834066795298815")},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newReduceV1Hint(ctx.operanders["x.d0"])
+				},
+				check: varValueInScopeEquals("value", bigIntString("0", 10)),
+			},
+			{
+				operanders: []*hintOperander{
+					// values are the 3 results of split(SEC_P + 5)
+					{Name: "x.d0", Kind: apRelative, Value: feltString("77371252455336262886226996")},
+					{Name: "x.d1", Kind: apRelative, Value: feltString("77371252455336267181195263")},
+					{Name: "x.d2", Kind: apRelative, Value: feltString("19342813113834066795298815")},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newReduceV1Hint(ctx.operanders["x.d0"])
+				},
+				check: varValueInScopeEquals("value", bigIntString("5", 10)),
+			},
 		},
 		"EcMulInner": {
 			{
@@ -845,6 +894,25 @@ func TestZeroHintEc(t *testing.T) {
 				},
 				check: apValueEquals(&utils.FeltOne),
 			},
+			{
+				operanders: []*hintOperander{
+					{Name: "scalar", Kind: apRelative, Value: &utils.FeltZero},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newEcMulInnerHint(ctx.operanders["scalar"])
+				},
+				check: apValueEquals(&utils.FeltZero),
+			},
+			{
+				operanders: []*hintOperander{
+					// PRIME - 2
+					{Name: "scalar", Kind: apRelative, Value: feltString("3618502788666131213697322783095070105623107215331596699973092056135872020479")},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newEcMulInnerHint(ctx.operanders["scalar"])
+				},
+				check: apValueEquals(&utils.FeltOne),
+			},
 		},
 		"IsZeroNondet": {
 			{
@@ -974,6 +1042,70 @@ func TestZeroHintEc(t *testing.T) {
 				check: varValueInScopeEquals("value", bigIntString("4", 10)),
 			},
 		},
+		"RecoverY": {
+			// the x coordinate of the STARK curve generator, whose y is the smallest root
+			{
+				operanders: []*hintOperander{
+					{Name: "x", Kind: apRelative, Value: feltString("0x1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca")},
+					{Name: "p.x", Kind: uninitialized},
+					{Name: "p.y", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newRecoverYHint(ctx.operanders["x"], ctx.operanders["p.x"])
+				},
+				check: consecutiveVarValueEquals("p.x", []*fp.Element{
+					feltString("0x1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca"),
+					feltString("0x5668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f"),
+				}),
+			},
+			// 5^3 + 5 + BETA isn't a square modulo FIELD_PRIME
+			{
+				operanders: []*hintOperander{
+					{Name: "x", Kind: apRelative, Value: feltUint64(5)},
+					{Name: "p.x", Kind: uninitialized},
+					{Name: "p.y", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newRecoverYHint(ctx.operanders["x"], ctx.operanders["p.x"])
+				},
+				errCheck: errorTextContains("5 does not represent the x coordinate of a point on the curve"),
+			},
+		},
 	},
 	)
 }
+
+func TestEcDoubleHintsChain(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	ctx := &hinter.HintRunnerContext{}
+	hinter.InitializeScopeManager(ctx, make(map[string]any))
+
+	// the secp256k1 generator point, then the slope written by nondet_bigint3
+	point := hinter.Deref{Deref: hinter.ApCellRef(0)}
+	slope := hinter.Deref{Deref: hinter.ApCellRef(6)}
+	writeBigInt3 := func(offset uint64, valueBig *big.Int) {
+		values, err := secp_utils.SecPSplit(new(big.Int).Set(valueBig))
+		require.NoError(t, err)
+		for i := range values {
+			secp_utils.WriteTo(vm, VM.ExecutionSegment, offset+uint64(i), memory.MemoryValueFromFieldElement(new(fp.Element).SetBigInt(&values[i])))
+		}
+	}
+	writeBigInt3(0, bigIntString("55066263022277343669578718895168534326250603453777594175500187360389116729240", 10))
+	writeBigInt3(3, bigIntString("32670510020758816978083085130507043184471273380659243275938904335757337482424", 10))
+
+	require.NoError(t, newEcDoubleSlopeV1Hint(point).Execute(vm, ctx))
+	slopeBig, err := ctx.ScopeManager.GetVariableValueAsBigInt("value")
+	require.NoError(t, err)
+	writeBigInt3(6, slopeBig)
+
+	// 2 * G
+	require.NoError(t, newEcDoubleAssignNewXV1Hint(slope, point).Execute(vm, ctx))
+	newX, err := ctx.ScopeManager.GetVariableValueAsBigInt("value")
+	require.NoError(t, err)
+	require.Equal(t, bigIntString("89565891926547004231252920425935692360644145829622209833684329913297188986597", 10), newX)
+
+	require.NoError(t, newEcDoubleAssignNewYV1Hint().Execute(vm, ctx))
+	newY, err := ctx.ScopeManager.GetVariableValueAsBigInt("value")
+	require.NoError(t, err)
+	require.Equal(t, bigIntString("12158399299693830322967808612713398636155367887041628176798871954788371653930", 10), newY)
+}
@@ -3,6 +3,7 @@ package zero
 import (
 	"fmt"
 	"math"
+	"math/big"
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/hinter"
 	"github.com/NethermindEth/cairo-vm-go/pkg/utils"
@@ -14,10 +15,22 @@ import (
 	"golang.org/x/crypto/sha3"
 )
 
-// CairoKeccakFinalize writes the result of F1600 Keccak permutation padded by __keccak_state_size_felts__ zeros to consecutive memory cells, __block_size__ times.
+// Constants of the `cairo_keccak` library used by `finalize_keccak`
+const (
+	// keccakStateSizeFelts is the number of 64-bit words of a Keccak-f[1600] state
+	keccakStateSizeFelts = 25
+	// keccakBlockSize is the number of Keccak instances packed together by the library
+	keccakBlockSize = 3
+)
+
+// CairoKeccakFinalize hint pads the Keccak builtin instances with dummy pairs of input
+// and output, so that the number of instances is a multiple of `BLOCK_SIZE`
 //
-// `CairoKeccakFinalize` takes 1 operander as argument
-//   - `keccakPtrEnd` is the address in memory where to start writing the result
+// A dummy pair is the all-zeros state of `KECCAK_STATE_SIZE_FELTS` words followed by
+// its Keccak-f[1600] permutation, and `BLOCK_SIZE` of them are written starting at `keccak_ptr_end`
+//
+// `newCairoKeccakFinalizeHint` takes 1 operander as argument
+//   - `keccakPtrEnd` is the address in memory where to start writing the padding
 func newCairoKeccakFinalizeHint(keccakPtrEnd hinter.ResOperander) hinter.Hinter {
 	return &GenericZeroHinter{
 		Name: "CairoKeccakFinalize",
@@ -30,24 +43,24 @@ func newCairoKeccakFinalizeHint(keccakPtrEnd hinter.ResOperander) hinter.Hinter
 			//> padding = (inp + keccak_func(inp)) * _block_size
 			//> segments.write_arg(ids.keccak_ptr_end, padding)
 
-			keccakStateSizeFeltsVal := uint64(25)
-			blockSizeVal := uint64(3)
+			var output [keccakStateSizeFelts]uint64
+			builtins.KeccakF1600(&output)
+
+			pair := make([]uint64, keccakStateSizeFelts, 2*keccakStateSizeFelts)
+			pair = append(pair, output[:]...)
 
-			var input [25]uint64
-			builtins.KeccakF1600(&input)
-			padding := make([]uint64, keccakStateSizeFeltsVal)
-			padding = append(padding, input[:]...)
-			result := make([]uint64, 0, keccakStateSizeFeltsVal*blockSizeVal)
-			for i := uint64(0); i < blockSizeVal; i++ {
-				result = append(result, padding...)
+			padding := make([]uint64, 0, len(pair)*keccakBlockSize)
+			for i := 0; i < keccakBlockSize; i++ {
+				padding = append(padding, pair...)
 			}
+
 			keccakPtrEnd, err := hinter.ResolveAsAddress(vm, keccakPtrEnd)
 			if err != nil {
 				return err
 			}
-			for i := 0; i < len(result); i++ {
-				resultMV := memory.MemoryValueFromUint(result[i])
-				err = vm.Memory.WriteToAddress(keccakPtrEnd, &resultMV)
+			for i := range padding {
+				paddingMV := memory.MemoryValueFromUint(padding[i])
+				err = vm.Memory.WriteToAddress(keccakPtrEnd, &paddingMV)
 				if err != nil {
 					return err
 				}
@@ -315,6 +328,8 @@ func createUnsafeKeccakFinalizeHinter(resolver hintReferenceResolver) (hinter.Hi
 //   - `inputs` is the address in memory where to write Keccak arguments
 //   - `low` is the low part of the `uint256` argument for the Keccac function
 //   - `high` is the high part of the `uint256` argument for the Keccac function
+//
+// Both parts must fit in 128 bits, they are written as four 64-bit words
 func newKeccakWriteArgsHint(inputs, low, high hinter.ResOperander) hinter.Hinter {
 	name := "KeccakWriteArgs"
 	return &GenericZeroHinter{
@@ -333,6 +348,15 @@ func newKeccakWriteArgsHint(inputs, low, high hinter.ResOperander) hinter.Hinter
 				return err
 			}
 
+			// Each part is split in two 64-bit words, so a larger value would
+			// leave its upper bits out of the Keccak arguments
+			if !utils.FeltLt(low, &utils.FeltMax128) {
+				return fmt.Errorf("low %v is not a 128-bit value", low)
+			}
+			if !utils.FeltLt(high, &utils.FeltMax128) {
+				return fmt.Errorf("high %v is not a 128-bit value", high)
+			}
+
 			inputsPtr, err := hinter.ResolveAsAddress(vm, inputs)
 			if err != nil {
 				return err
@@ -390,17 +414,17 @@ func newKeccakWriteArgsHint(inputs, low, high hinter.ResOperander) hinter.Hinter
 
 func createKeccakWriteArgsHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
 	inputs, err := resolver.GetResOperander("inputs")
-	if inputs != nil {
+	if err != nil {
 		return nil, err
 	}
 
 	low, err := resolver.GetResOperander("low")
-	if low != nil {
+	if err != nil {
 		return nil, err
 	}
 
 	high, err := resolver.GetResOperander("high")
-	if high != nil {
+	if err != nil {
 		return nil, err
 	}
 
@@ -409,11 +433,12 @@ func createKeccakWriteArgsHinter(resolver hintReferenceResolver) (hinter.Hinter,
 
 // CompareKeccakFullRateInBytes hint compares a value to KECCAK_FULL_RATE_IN_BYTES constant, i.e., 136
 //
-// `newKeccakWriteArgsHint` takes 1 operander as argument
+// `newCompareKeccakFullRateInBytesHint` takes 1 operander as argument
 //   - `nBytes` is the value to be compared with KECCAK_FULL_RATE_IN_BYTES
 //
-// `newKeccakWriteArgsHint` writes 1 or 0 to `ap` memory address depending on whether
-// `n_bytes` is greater or equal to KECCAK_FULL_RATE_IN_BYTES or not
+// `newCompareKeccakFullRateInBytesHint` writes 1 or 0 to `ap` memory address depending on whether
+// `n_bytes` is greater or equal to KECCAK_FULL_RATE_IN_BYTES or not, which decides whether
+// the Keccak absorption loop processes another full block
 func newCompareKeccakFullRateInBytesHint(nBytes hinter.ResOperander) hinter.Hinter {
 	return &GenericZeroHinter{
 		Name: "CompareKeccakFullRateInBytes",
@@ -421,16 +446,17 @@ func newCompareKeccakFullRateInBytesHint(nBytes hinter.ResOperander) hinter.Hint
 			//> python hint: ids.n_bytes >= ids.KECCAK_FULL_RATE_IN_BYTES
 			//> JSON file hint: memory[ap] = to_felt_or_relocatable(ids.n_bytes >= ids.KECCAK_FULL_RATE_IN_BYTES)
 
-			// n_bytes should fit into a uint64
-			// we cannot 100% exclude the possibility that it doesn't
-			nBytesVal, err := hinter.ResolveAsUint64(vm, nBytes)
+			// n_bytes is compared as a felt, so that a value that doesn't fit
+			// into a uint64 is still greater than the rate, as in the Python VM
+			nBytesVal, err := hinter.ResolveAsFelt(vm, nBytes)
 			if err != nil {
 				return err
 			}
 
+			fullRate := new(fp.Element).SetUint64(utils.KECCAK_FULL_RATE_IN_BYTES)
 			apAddr := vm.Context.AddressAp()
 			var resultMv memory.MemoryValue
-			if nBytesVal >= uint64(utils.KECCAK_FULL_RATE_IN_BYTES) {
+			if !utils.FeltLt(nBytesVal, fullRate) {
 				resultMv = memory.MemoryValueFromFieldElement(&utils.FeltOne)
 			} else {
 				resultMv = memory.MemoryValueFromFieldElement(&utils.FeltZero)
@@ -572,3 +598,77 @@ func createCompareBytesInWordNondetHinter(resolver hintReferenceResolver) (hinte
 
 	return newCompareBytesInWordHint(nBytes), nil
 }
+
+// SplitInput hint splits a word of a Keccak input buffer at a byte boundary
+//
+// `newSplitInputHint` takes 3 operanders and 1 parameter as arguments
+//   - `high` and `low` are the variables that will store the two parts of the word
+//   - `inputs` is the pointer to the input buffer
+//   - `nBytes` is the number of bytes kept in `low`, the word being read at
+//     offset `3 * nBytes` of the buffer
+//
+// `newSplitInputHint` covers all the `split_input_N` variants of the Keccak
+// library, with N = 3 * nBytes
+func newSplitInputHint(high, low, inputs hinter.ResOperander, nBytes uint64) hinter.Hinter {
+	return &GenericZeroHinter{
+		Name:     fmt.Sprintf("SplitInput%d", 3*nBytes),
+		Operands: []string{fmt.Sprintf("high%d", 3*nBytes), fmt.Sprintf("low%d", 3*nBytes), "inputs"},
+		Op: func(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+			//> ids.high{3 * nBytes}, ids.low{3 * nBytes} = divmod(memory[ids.inputs + 3 * nBytes], 256 ** nBytes)
+
+			inputsPtr, err := hinter.ResolveAsAddress(vm, inputs)
+			if err != nil {
+				return err
+			}
+
+			word, err := vm.Memory.ReadAsElement(inputsPtr.SegmentIndex, inputsPtr.Offset+3*nBytes)
+			if err != nil {
+				return err
+			}
+
+			var wordBig big.Int
+			word.BigInt(&wordBig)
+
+			divisor := new(big.Int).Lsh(big.NewInt(1), uint(8*nBytes))
+			highBig, lowBig := new(big.Int).DivMod(&wordBig, divisor, new(big.Int))
+
+			highAddr, err := high.GetAddress(vm)
+			if err != nil {
+				return err
+			}
+			highMv := memory.MemoryValueFromFieldElement(new(fp.Element).SetBigInt(highBig))
+			err = vm.Memory.WriteToAddress(&highAddr, &highMv)
+			if err != nil {
+				return err
+			}
+
+			lowAddr, err := low.GetAddress(vm)
+			if err != nil {
+				return err
+			}
+			lowMv := memory.MemoryValueFromFieldElement(new(fp.Element).SetBigInt(lowBig))
+			return vm.Memory.WriteToAddress(&lowAddr, &lowMv)
+		},
+	}
+}
+
+func createSplitInputHinter(resolver hintReferenceResolver, nBytes uint64) (hinter.Hinter, error) {
+	index := 3 * nBytes
+
+	high, err := resolver.GetResOperander(fmt.Sprintf("high%d", index))
+	if err != nil {
+		return nil, err
+	}
+
+	low, err := resolver.GetResOperander(fmt.Sprintf("low%d", index))
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := resolver.GetResOperander("inputs")
+	if err != nil {
+		return nil, err
+	}
+
+	return newSplitInputHint(high, low, inputs, nBytes), nil
+}
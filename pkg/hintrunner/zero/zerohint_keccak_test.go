@@ -7,9 +7,36 @@ import (
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/hinter"
 	"github.com/NethermindEth/cairo-vm-go/pkg/utils"
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/require"
 )
 
 func TestZeroHintKeccak(t *testing.T) {
+	// splitInputOperanders lays out a 16 words input buffer, known for the
+	// words read by the split_input hints and zero everywhere else
+	splitInputOperanders := func() []*hintOperander {
+		words := map[int]uint64{
+			3:  0x0102030405,
+			6:  0xaabbccddeeff,
+			9:  0x1122334455,
+			12: 0xdeadbeefcafe,
+			15: 0x1122334455667788,
+		}
+		operanders := []*hintOperander{
+			{Name: "inputs", Kind: apRelative, Value: addr(5)},
+		}
+		for i := 0; i < 16; i++ {
+			operanders = append(operanders, &hintOperander{
+				Name:  fmt.Sprintf("inputs.%d", i),
+				Kind:  apRelative,
+				Value: feltUint64(words[i]),
+			})
+		}
+		return append(operanders,
+			&hintOperander{Name: "high", Kind: uninitialized},
+			&hintOperander{Name: "low", Kind: uninitialized},
+		)
+	}
+
 	runHinterTests(t, map[string][]hintTestCase{
 		"CairoKeccakFinalize": {
 			{
@@ -45,6 +72,13 @@ func TestZeroHintKeccak(t *testing.T) {
 						testValuesFelt[i] = feltUint64(v)
 					}
 					consecutiveVarAddrResolvedValueEquals("keccak_ptr_end", testValuesFelt)(t, ctx)
+
+					// exactly BLOCK_SIZE pairs of KECCAK_STATE_SIZE_FELTS input and output words are written
+					written := uint64(2 * keccakStateSizeFelts * keccakBlockSize)
+					for offset := uint64(10); offset < 10+written; offset++ {
+						require.True(t, ctx.vm.Memory.KnownValueAtAddress(addr(offset)), "padding[%d] is not written", offset-10)
+					}
+					require.False(t, ctx.vm.Memory.KnownValueAtAddress(addr(10+written)), "padding is longer than %d words", written)
 				},
 			},
 		},
@@ -144,6 +178,46 @@ func TestZeroHintKeccak(t *testing.T) {
 			},
 		},
 		"KeccakWriteArgs": {
+			{
+				operanders: []*hintOperander{
+					{Name: "inputs", Kind: apRelative, Value: addr(7)},
+					{Name: "low", Kind: fpRelative, Value: feltString("0x0123456789abcdeffedcba9876543210")},
+					{Name: "high", Kind: fpRelative, Value: new(fp.Element).Sub(&utils.FeltMax128, &utils.FeltOne)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newKeccakWriteArgsHint(ctx.operanders["inputs"], ctx.operanders["low"], ctx.operanders["high"])
+				},
+				check: consecutiveVarAddrResolvedValueEquals(
+					"inputs",
+					[]*fp.Element{
+						feltString("18364758544493064720"),
+						feltString("81985529216486895"),
+						feltString("18446744073709551615"),
+						feltString("18446744073709551615"),
+					}),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "inputs", Kind: apRelative, Value: addr(7)},
+					{Name: "low", Kind: fpRelative, Value: &utils.FeltMax128},
+					{Name: "high", Kind: fpRelative, Value: feltUint64(0)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newKeccakWriteArgsHint(ctx.operanders["inputs"], ctx.operanders["low"], ctx.operanders["high"])
+				},
+				errCheck: errorTextContains("low 340282366920938463463374607431768211456 is not a 128-bit value"),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "inputs", Kind: apRelative, Value: addr(7)},
+					{Name: "low", Kind: fpRelative, Value: feltUint64(0)},
+					{Name: "high", Kind: fpRelative, Value: feltInt64(-1)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newKeccakWriteArgsHint(ctx.operanders["inputs"], ctx.operanders["low"], ctx.operanders["high"])
+				},
+				errCheck: errorTextContains("is not a 128-bit value"),
+			},
 			{
 				operanders: []*hintOperander{
 					{Name: "inputs", Kind: apRelative, Value: addr(7)},
@@ -458,6 +532,25 @@ func TestZeroHintKeccak(t *testing.T) {
 				},
 				check: apValueEquals(feltUint64(0)),
 			},
+			{
+				operanders: []*hintOperander{
+					{Name: "n_bytes", Kind: fpRelative, Value: feltUint64(0)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newCompareKeccakFullRateInBytesHint(ctx.operanders["n_bytes"])
+				},
+				check: apValueEquals(feltUint64(0)),
+			},
+			{
+				operanders: []*hintOperander{
+					// 2**64 + 135 doesn't fit into a uint64
+					{Name: "n_bytes", Kind: fpRelative, Value: feltString("18446744073709551751")},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newCompareKeccakFullRateInBytesHint(ctx.operanders["n_bytes"])
+				},
+				check: apValueEquals(feltUint64(1)),
+			},
 		},
 		"BlockPermutation": {
 			{
@@ -585,6 +678,70 @@ func TestZeroHintKeccak(t *testing.T) {
 					}),
 			},
 		},
+		"SplitInput": {
+			{
+				operanders: splitInputOperanders(),
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSplitInputHint(ctx.operanders["high"], ctx.operanders["low"], ctx.operanders["inputs"], 1)
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					varValueEquals("high", feltUint64(0x01020304))(t, ctx)
+					varValueEquals("low", feltUint64(0x05))(t, ctx)
+				},
+			},
+			{
+				operanders: splitInputOperanders(),
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSplitInputHint(ctx.operanders["high"], ctx.operanders["low"], ctx.operanders["inputs"], 2)
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					varValueEquals("high", feltUint64(0xaabbccdd))(t, ctx)
+					varValueEquals("low", feltUint64(0xeeff))(t, ctx)
+				},
+			},
+			{
+				operanders: splitInputOperanders(),
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSplitInputHint(ctx.operanders["high"], ctx.operanders["low"], ctx.operanders["inputs"], 3)
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					varValueEquals("high", feltUint64(0x1122))(t, ctx)
+					varValueEquals("low", feltUint64(0x334455))(t, ctx)
+				},
+			},
+			{
+				operanders: splitInputOperanders(),
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSplitInputHint(ctx.operanders["high"], ctx.operanders["low"], ctx.operanders["inputs"], 4)
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					varValueEquals("high", feltUint64(0xdead))(t, ctx)
+					varValueEquals("low", feltUint64(0xbeefcafe))(t, ctx)
+				},
+			},
+			{
+				operanders: splitInputOperanders(),
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSplitInputHint(ctx.operanders["high"], ctx.operanders["low"], ctx.operanders["inputs"], 5)
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					varValueEquals("high", feltUint64(0x112233))(t, ctx)
+					varValueEquals("low", feltUint64(0x4455667788))(t, ctx)
+				},
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "inputs", Kind: apRelative, Value: addr(5)},
+					{Name: "inputs.0", Kind: apRelative, Value: feltUint64(0)},
+					{Name: "high", Kind: uninitialized},
+					{Name: "low", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSplitInputHint(ctx.operanders["high"], ctx.operanders["low"], ctx.operanders["inputs"], 5)
+				},
+				errCheck: errorTextContains("unknown value"),
+			},
+		},
 		"CompareBytesInWordHint": {
 			{
 				operanders: []*hintOperander{
@@ -138,7 +138,7 @@ func newAssertNotZeroHint(value hinter.ResOperander) hinter.Hinter {
 			}
 
 			if value.IsZero() {
-				return fmt.Errorf("assertion failed: value is zero")
+				return fmt.Errorf("assert_not_zero failed: value is zero")
 			}
 
 			return nil
@@ -254,14 +254,16 @@ func createAssertNotEqualHinter(resolver hintReferenceResolver) (hinter.Hinter,
 
 // Assert250bits hint asserts that a value is within the range of 250 bits
 //
-// `newAssert250bitsHint` takes 3 operanders as arguments
+// `newAssert250bitsHint` takes 5 operanders as arguments
 //   - `value` is the value that will be evaluated
 //   - `low` and `high` are the variables that will store the quotient and
-//     remainder of the modular division of `value` by 2**128
+//     remainder of the modular division of `value` by `SHIFT`
+//   - `upperBound` and `shift` are the `UPPER_BOUND` and `SHIFT` program constants.
+//     They can be nil, in which case the standard 2**250 and 2**128 are used
 //
 // `newAssert250bitsHint` writes the quotient and the remainder of the modular
-// division of `value` by 2**128 at `low` and `high` addresses in memory, respectively
-func newAssert250bitsHint(low, high, value hinter.ResOperander) hinter.Hinter {
+// division of `value` by `SHIFT` at `high` and `low` addresses in memory, respectively
+func newAssert250bitsHint(low, high, value, upperBound, shift hinter.ResOperander) hinter.Hinter {
 	return &GenericZeroHinter{
 		Name: "Assert250bits",
 		Op: func(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
@@ -280,8 +282,29 @@ func newAssert250bitsHint(low, high, value hinter.ResOperander) hinter.Hinter {
 				return err
 			}
 
-			if !utils.FeltLt(value, &utils.FeltUpperBound) {
-				return fmt.Errorf("assertion failed: %v is outside of the range [0, 2**250)", value)
+			upperBoundValue := &utils.FeltUpperBound
+			upperBoundText := "2**250"
+			if upperBound != nil {
+				upperBoundValue, err = hinter.ResolveAsFelt(vm, upperBound)
+				if err != nil {
+					return err
+				}
+				upperBoundText = upperBoundValue.String()
+			}
+
+			shiftValue := &utils.FeltMax128
+			if shift != nil {
+				shiftValue, err = hinter.ResolveAsFelt(vm, shift)
+				if err != nil {
+					return err
+				}
+				if shiftValue.IsZero() {
+					return fmt.Errorf("SHIFT cannot be zero")
+				}
+			}
+
+			if !utils.FeltLt(value, upperBoundValue) {
+				return fmt.Errorf("assertion failed: %v is outside of the range [0, %s)", value, upperBoundText)
 			}
 
 			lowAddr, err := low.GetAddress(vm)
@@ -294,7 +317,7 @@ func newAssert250bitsHint(low, high, value hinter.ResOperander) hinter.Hinter {
 				return err
 			}
 
-			div, rem := utils.FeltDivRem(value, &utils.FeltMax128)
+			div, rem := utils.FeltDivRem(value, shiftValue)
 
 			// div goes to high, rem goes to low.
 			divValue := memory.MemoryValueFromFieldElement(&div)
@@ -333,7 +356,19 @@ func createAssert250bitsHinter(resolver hintReferenceResolver) (hinter.Hinter, e
 		return nil, err
 	}
 
-	return newAssert250bitsHint(low, high, value), nil
+	// UPPER_BOUND and SHIFT are program constants, they fall back
+	// to the standard values when the program doesn't declare them
+	upperBound, err := resolver.getOptionalResOperander("UPPER_BOUND")
+	if err != nil {
+		return nil, err
+	}
+
+	shift, err := resolver.getOptionalResOperander("SHIFT")
+	if err != nil {
+		return nil, err
+	}
+
+	return newAssert250bitsHint(low, high, value, upperBound, shift), nil
 }
 
 // AsserLeFelt hint assert that one value is less than or equal to another
@@ -342,12 +377,44 @@ func createAssert250bitsHinter(resolver hintReferenceResolver) (hinter.Hinter, e
 // `newAssertLeFeltHint` takes 3 operanders as arguments
 //   - `a` and `b` is the values that will be evaluated
 //   - `rangeCheckPtr` is a pointer to the range-check builtin
+//
+// `newAssertLeFeltHint` writes 4 cells starting at `rangeCheckPtr` without
+// moving it: the `assert_le_felt` Cairo function advances `range_check_ptr`
+// by exactly 4 right after the hint
+//
+// The excluded arc, i.e. the longest one among `a`, `b - a` and `PRIME - 1 - b`,
+// is stored in scope as `excluded`. `assert_le_felt` then jumps to the branch
+// checking the two other arcs with the `AssertLeFeltExcluded` hints
 func newAssertLeFeltHint(a, b, rangeCheckPtr hinter.ResOperander) hinter.Hinter {
-	return &core.AssertLeFindSmallArc{
+	findSmallArc := &core.AssertLeFindSmallArc{
 		A:             a,
 		B:             b,
 		RangeCheckPtr: rangeCheckPtr,
 	}
+	return &GenericZeroHinter{
+		Name: "AssertLeFelt",
+		Op: func(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+			//> a = ids.a % PRIME
+			//> b = ids.b % PRIME
+			//> assert a <= b, f'a = {a} is not less than or equal to b = {b}.'
+
+			aFelt, err := hinter.ResolveAsFelt(vm, a)
+			if err != nil {
+				return err
+			}
+			bFelt, err := hinter.ResolveAsFelt(vm, b)
+			if err != nil {
+				return err
+			}
+			if !utils.FeltLe(aFelt, bFelt) {
+				return fmt.Errorf("a = %v is not less than or equal to b = %v", aFelt, bFelt)
+			}
+
+			// The two shortest arcs always fit in PRIME // 3 and PRIME // 2 once a <= b,
+			// which makes the remaining assertion of the hint always hold
+			return findSmallArc.Execute(vm, ctx)
+		},
+	}
 }
 
 func createAssertLeFeltHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
@@ -450,6 +517,8 @@ func createIsNNHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
 
 // IsNNOutOfRange hint checks if the negation of a value minus one
 // is non-negative within a specific range
+// It is the fallback of IsNN hint in `is_nn` Cairo function, executed when `a`
+// is not in the range `[0, range_check_builtin.bound)`, so both hints share the same bound
 //
 // `newIsNNOutOfRangeHint` takes 1 operander as argument
 //   - `a` is the value that will be evaluated
@@ -473,7 +542,7 @@ func newIsNNOutOfRangeHint(a hinter.ResOperander) hinter.Hinter {
 			lhs.Sub(&utils.FeltZero, a) //> -ids.a
 			lhs.Sub(&lhs, &utils.FeltOne)
 			var v memory.MemoryValue
-			if utils.FeltLt(&lhs, &utils.FeltMax128) {
+			if utils.FeltIsPositive(&lhs) {
 				v = memory.MemoryValueFromFieldElement(&utils.FeltZero)
 			} else {
 				v = memory.MemoryValueFromFieldElement(&utils.FeltOne)
@@ -770,7 +839,8 @@ func createSignedPowHinter(resolver hintReferenceResolver) (hinter.Hinter, error
 //   - `value` is the variable to split
 //
 // `newSplitFeltHint` writes the low and high components in the `low` and `high`
-// memory address, respectively
+// memory address, respectively. These are the first two range check cells, which
+// the `split_felt` Cairo function then skips by advancing `range_check_ptr` by 2
 func newSplitFeltHint(low, high, value hinter.ResOperander) hinter.Hinter {
 	return &GenericZeroHinter{
 		Name: "SplitFelt",
@@ -1127,6 +1197,13 @@ func createUnsignedDivRemHinter(resolver hintReferenceResolver) (hinter.Hinter,
 	return newUnsignedDivRemHint(value, div, q, r), nil
 }
 
+// IsQuadResidue hint computes the square root of `x` if it is a quadratic residue,
+// or the square root of `x / 3` otherwise. The Cairo code then checks which of the
+// two cases holds, so the hint doesn't write whether `x` is a quadratic residue
+//
+// `newIsQuadResidueHint` takes 2 operanders as arguments
+//   - `x` is the felt to compute the square root of
+//   - `y` is the variable that will store the smallest of the two square roots
 func newIsQuadResidueHint(x, y hinter.ResOperander) hinter.Hinter {
 	return &GenericZeroHinter{
 		Name: "IsQuadResidue",
@@ -1149,58 +1226,209 @@ func newIsQuadResidueHint(x, y hinter.ResOperander) hinter.Hinter {
 				return err
 			}
 
-			xBigInt := math_utils.AsInt(x)
+			// 0 is a quadratic residue too, its only square root being 0
+			radicand := new(fp.Element).Set(x)
+			if x.Legendre() == -1 {
+				radicand.Div(x, new(fp.Element).SetUint64(3))
+			}
+
+			root := new(fp.Element).Sqrt(radicand)
+			if root == nil {
+				return fmt.Errorf("%v has no square root", radicand)
+			}
+
+			// `sqrt` returns the smallest of the two square roots
+			if root.LexicographicallyLargest() {
+				root.Neg(root)
+			}
+
+			value := memory.MemoryValueFromFieldElement(root)
+			return vm.Memory.WriteToAddress(&yAddr, &value)
+		},
+	}
+}
+
+func createIsQuadResidueHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
+	x, err := resolver.GetResOperander("x")
+	if err != nil {
+		return nil, err
+	}
+	y, err := resolver.GetResOperander("y")
+	if err != nil {
+		return nil, err
+	}
+
+	return newIsQuadResidueHint(x, y), nil
+}
+
+// IsAddrBounded hint checks if an address is lower than `ADDR_BOUND`, after
+// verifying the assumptions `normalize_address` makes on this constant
+//
+// `newIsAddrBoundedHint` takes 3 operanders as arguments
+//   - `addr` is the address that will be evaluated
+//   - `addrBound` is the `ADDR_BOUND` constant
+//   - `isSmall` is the variable that will store the result of the comparison
+//
+// `newIsAddrBoundedHint` writes 1 to `isSmall` if `addr < ADDR_BOUND`, 0 otherwise
+func newIsAddrBoundedHint(addr, addrBound, isSmall hinter.ResOperander) hinter.Hinter {
+	return &GenericZeroHinter{
+		Name:     "IsAddrBounded",
+		Operands: []string{"addr", "ADDR_BOUND", "is_small"},
+		Op: func(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+			//> # Verify the assumptions on the relationship between 2**250, ADDR_BOUND and PRIME.
+			//> ADDR_BOUND = ids.ADDR_BOUND % PRIME
+			//> assert (2**250 < ADDR_BOUND <= 2**251) and (2 * 2**250 < PRIME) and (
+			//>         ADDR_BOUND * 2 > PRIME), \
+			//>     'normalize_address() cannot be used with the current constants.'
+			//> ids.is_small = 1 if ids.addr < ADDR_BOUND else 0
+
+			addrValue, err := hinter.ResolveAsFelt(vm, addr)
+			if err != nil {
+				return err
+			}
+
+			addrBoundValue, err := hinter.ResolveAsFelt(vm, addrBound)
+			if err != nil {
+				return err
+			}
 
-			var value = memory.MemoryValue{}
+			// 2 * 2**250 < PRIME always holds for the Starknet field
+			var addrBoundBig, doubleAddrBound big.Int
+			addrBoundValue.BigInt(&addrBoundBig)
+			doubleAddrBound.Lsh(&addrBoundBig, 1)
+			max251 := new(fp.Element).Double(&utils.FeltUpperBound)
+			if !utils.FeltLt(&utils.FeltUpperBound, addrBoundValue) || !utils.FeltLe(addrBoundValue, max251) ||
+				doubleAddrBound.Cmp(fp.Modulus()) <= 0 {
+				return fmt.Errorf("normalize_address() cannot be used with the current constants")
+			}
 
-			if x.IsZero() || x.IsOne() {
-				value = memory.MemoryValueFromFieldElement(x)
+			isSmallAddr, err := isSmall.GetAddress(vm)
+			if err != nil {
+				return err
+			}
 
+			var v memory.MemoryValue
+			if utils.FeltLt(addrValue, addrBoundValue) {
+				v = memory.MemoryValueFromFieldElement(&utils.FeltOne)
 			} else {
-				var result *fp.Element = new(fp.Element)
+				v = memory.MemoryValueFromFieldElement(&utils.FeltZero)
+			}
 
-				if x.Legendre() == 1 {
-					// result = x.Sqrt(x)
+			return vm.Memory.WriteToAddress(&isSmallAddr, &v)
+		},
+	}
+}
 
-					const primeString = "3618502788666131213697322783095070105623107215331596699973092056135872020481"
-					primeBigInt, ok := new(big.Int).SetString(primeString, 10)
-					if !ok {
-						panic("failed to convert prime string to big.Int")
-					}
+func createIsAddrBoundedHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
+	addr, err := resolver.GetResOperander("addr")
+	if err != nil {
+		return nil, err
+	}
 
-					// divide primeBigInt by 2
-					halfPrimeBigInt := new(big.Int).Rsh(primeBigInt, 1)
+	addrBound, err := resolver.GetResOperander("ADDR_BOUND")
+	if err != nil {
+		return nil, err
+	}
 
-					tempResult := new(big.Int).ModSqrt(&xBigInt, primeBigInt)
+	isSmall, err := resolver.GetResOperander("is_small")
+	if err != nil {
+		return nil, err
+	}
 
-					// ensures that tempResult is the smaller of the two possible square roots in the prime field.
-					if tempResult.Cmp(halfPrimeBigInt) > 0 {
-						tempResult.Sub(primeBigInt, tempResult)
-					}
+	return newIsAddrBoundedHint(addr, addrBound, isSmall), nil
+}
 
-					result.SetBigInt(tempResult)
+// Is250Bits hint checks if an address fits in 250 bits
+//
+// `newIs250BitsHint` takes 2 operanders as arguments
+//   - `addr` is the address that will be evaluated
+//   - `is250` is the variable that will store the result of the comparison
+//
+// `newIs250BitsHint` writes 1 to `is250` if `addr < 2**250`, 0 otherwise
+func newIs250BitsHint(addr, is250 hinter.ResOperander) hinter.Hinter {
+	return &GenericZeroHinter{
+		Name:     "Is250Bits",
+		Operands: []string{"addr", "is_250"},
+		Op: func(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+			//> ids.is_250 = 1 if ids.addr < 2**250 else 0
 
-				} else {
-					result = x.Sqrt(new(fp.Element).Div(x, new(fp.Element).SetUint64(3)))
-				}
+			addrValue, err := hinter.ResolveAsFelt(vm, addr)
+			if err != nil {
+				return err
+			}
 
-				value = memory.MemoryValueFromFieldElement(result)
+			is250Addr, err := is250.GetAddress(vm)
+			if err != nil {
+				return err
 			}
 
-			return vm.Memory.WriteToAddress(&yAddr, &value)
+			var v memory.MemoryValue
+			if utils.FeltLt(addrValue, &utils.FeltUpperBound) {
+				v = memory.MemoryValueFromFieldElement(&utils.FeltOne)
+			} else {
+				v = memory.MemoryValueFromFieldElement(&utils.FeltZero)
+			}
+
+			return vm.Memory.WriteToAddress(&is250Addr, &v)
 		},
 	}
 }
 
-func createIsQuadResidueHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
+func createIs250BitsHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
+	addr, err := resolver.GetResOperander("addr")
+	if err != nil {
+		return nil, err
+	}
+
+	is250, err := resolver.GetResOperander("is_250")
+	if err != nil {
+		return nil, err
+	}
+
+	return newIs250BitsHint(addr, is250), nil
+}
+
+// GetFeltBitLength hint computes the number of bits needed to represent a felt
+//
+// `newGetFeltBitLengthHint` takes 2 operanders as arguments
+//   - `x` is the felt whose bit length is computed, as an integer in the range [0, PRIME)
+//   - `bitLength` is the variable that will store the bit length, which is 0 for `x = 0`
+func newGetFeltBitLengthHint(x, bitLength hinter.ResOperander) hinter.Hinter {
+	return &GenericZeroHinter{
+		Name:     "GetFeltBitLength",
+		Operands: []string{"x", "bit_length"},
+		Op: func(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+			//> x = ids.x
+			//> ids.bit_length = x.bit_length()
+
+			xFelt, err := hinter.ResolveAsFelt(vm, x)
+			if err != nil {
+				return err
+			}
+
+			bitLengthAddr, err := bitLength.GetAddress(vm)
+			if err != nil {
+				return err
+			}
+
+			var xBig big.Int
+			xFelt.BigInt(&xBig)
+			bitLengthMv := memory.MemoryValueFromUint(uint64(xBig.BitLen()))
+			return vm.Memory.WriteToAddress(&bitLengthAddr, &bitLengthMv)
+		},
+	}
+}
+
+func createGetFeltBitLengthHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
 	x, err := resolver.GetResOperander("x")
 	if err != nil {
 		return nil, err
 	}
-	y, err := resolver.GetResOperander("y")
+
+	bitLength, err := resolver.GetResOperander("bit_length")
 	if err != nil {
 		return nil, err
 	}
 
-	return newIsQuadResidueHint(x, y), nil
+	return newGetFeltBitLengthHint(x, bitLength), nil
 }
@@ -8,7 +8,10 @@ import (
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/hinter"
 	"github.com/NethermindEth/cairo-vm-go/pkg/parsers/starknet"
 	"github.com/NethermindEth/cairo-vm-go/pkg/utils"
+	VM "github.com/NethermindEth/cairo-vm-go/pkg/vm"
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/builtins"
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/require"
 )
 
 func TestZeroHintMath(t *testing.T) {
@@ -98,7 +101,7 @@ func TestZeroHintMath(t *testing.T) {
 				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
 					return newAssertNotZeroHint(ctx.operanders["value"])
 				},
-				errCheck: errorTextContains("assertion failed: value is zero"),
+				errCheck: errorTextContains("assert_not_zero failed: value is zero"),
 			},
 			{
 				operanders: []*hintOperander{
@@ -217,6 +220,199 @@ func TestZeroHintMath(t *testing.T) {
 				errCheck: errorTextContains("assertion failed: -1 = -1"),
 			},
 		},
+		"AssertLeFelt": {
+			{
+				vmInit: func(vm *VM.VirtualMachine) {
+					vm.Memory.AllocateBuiltinSegment(&builtins.RangeCheck{})
+				},
+				operanders: []*hintOperander{
+					{Name: "a", Kind: apRelative, Value: feltUint64(1024)},
+					{Name: "b", Kind: apRelative, Value: feltUint64(1025)},
+					{Name: "range_check_ptr", Kind: apRelative, Value: addrWithSegment(2, 0)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newAssertLeFeltHint(ctx.operanders["a"], ctx.operanders["b"], ctx.operanders["range_check_ptr"])
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					// The hint doesn't move range_check_ptr, the library advances it
+					// by 4 right after, which must be the number of cells written
+					rangeCheckPtr, err := hinter.ResolveAsAddress(ctx.vm, ctx.operanders["range_check_ptr"])
+					require.NoError(t, err)
+					require.Equal(t, addrWithSegment(2, 0), rangeCheckPtr)
+					require.Equal(t, uint64(4), ctx.vm.Memory.Segments[rangeCheckPtr.SegmentIndex].Len())
+
+					consecutiveVarAddrResolvedValueEquals("range_check_ptr", []*fp.Element{
+						feltUint64(1), feltUint64(0), feltUint64(1024), feltUint64(0),
+					})(t, ctx)
+					varValueInScopeEquals("excluded", 2)(t, ctx)
+				},
+			},
+			{
+				// arcs: a = PRIME - 100, b - a = 90, PRIME - 1 - b = 9, the first arc is excluded
+				vmInit: func(vm *VM.VirtualMachine) {
+					vm.Memory.AllocateBuiltinSegment(&builtins.RangeCheck{})
+				},
+				operanders: []*hintOperander{
+					{Name: "a", Kind: apRelative, Value: feltInt64(-100)},
+					{Name: "b", Kind: apRelative, Value: feltInt64(-10)},
+					{Name: "range_check_ptr", Kind: apRelative, Value: addrWithSegment(2, 0)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newAssertLeFeltHint(ctx.operanders["a"], ctx.operanders["b"], ctx.operanders["range_check_ptr"])
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					consecutiveVarAddrResolvedValueEquals("range_check_ptr", []*fp.Element{
+						feltUint64(9), feltUint64(0), feltUint64(90), feltUint64(0),
+					})(t, ctx)
+					varValueInScopeEquals("excluded", 0)(t, ctx)
+				},
+			},
+			{
+				// arcs: a = 5, b - a = PRIME - 15, PRIME - 1 - b = 9, the second arc is excluded
+				vmInit: func(vm *VM.VirtualMachine) {
+					vm.Memory.AllocateBuiltinSegment(&builtins.RangeCheck{})
+				},
+				operanders: []*hintOperander{
+					{Name: "a", Kind: apRelative, Value: feltUint64(5)},
+					{Name: "b", Kind: apRelative, Value: feltInt64(-10)},
+					{Name: "range_check_ptr", Kind: apRelative, Value: addrWithSegment(2, 0)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newAssertLeFeltHint(ctx.operanders["a"], ctx.operanders["b"], ctx.operanders["range_check_ptr"])
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					consecutiveVarAddrResolvedValueEquals("range_check_ptr", []*fp.Element{
+						feltUint64(5), feltUint64(0), feltUint64(9), feltUint64(0),
+					})(t, ctx)
+					varValueInScopeEquals("excluded", 1)(t, ctx)
+				},
+			},
+			{
+				// arcs: a = 2**200, b - a = 2**250 - 2**200, PRIME - 1 - b = 2**250 + 17 * 2**192,
+				// both kept arcs are split with a non-zero high part
+				vmInit: func(vm *VM.VirtualMachine) {
+					vm.Memory.AllocateBuiltinSegment(&builtins.RangeCheck{})
+				},
+				operanders: []*hintOperander{
+					{Name: "a", Kind: apRelative, Value: feltString("1606938044258990275541962092341162602522202993782792835301376")},
+					{Name: "b", Kind: apRelative, Value: &utils.FeltUpperBound},
+					{Name: "range_check_ptr", Kind: apRelative, Value: addrWithSegment(2, 0)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newAssertLeFeltHint(ctx.operanders["a"], ctx.operanders["b"], ctx.operanders["range_check_ptr"])
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					consecutiveVarAddrResolvedValueEquals("range_check_ptr", []*fp.Element{
+						feltString("1397216016019607668675575808"), feltString("453347182355485927145472"), feltString("5316911981696065729389820261099076673"), feltString("340282366920938151196890927676487664575"),
+					})(t, ctx)
+					varValueInScopeEquals("excluded", 2)(t, ctx)
+				},
+			},
+			{
+				vmInit: func(vm *VM.VirtualMachine) {
+					vm.Memory.AllocateBuiltinSegment(&builtins.RangeCheck{})
+				},
+				operanders: []*hintOperander{
+					{Name: "a", Kind: apRelative, Value: feltUint64(11)},
+					{Name: "b", Kind: apRelative, Value: feltUint64(10)},
+					{Name: "range_check_ptr", Kind: apRelative, Value: addrWithSegment(2, 0)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newAssertLeFeltHint(ctx.operanders["a"], ctx.operanders["b"], ctx.operanders["range_check_ptr"])
+				},
+				errCheck: errorTextContains("a = 11 is not less than or equal to b = 10"),
+			},
+		},
+		"IsAddrBounded": {
+			// ADDR_BOUND = 2**251 - 256
+			{
+				operanders: []*hintOperander{
+					{Name: "addr", Kind: apRelative, Value: feltString("3618502788666131106986593281521497120414687020801267626233049500247285300991")},
+					{Name: "ADDR_BOUND", Kind: immediate, Value: feltString("3618502788666131106986593281521497120414687020801267626233049500247285300992")},
+					{Name: "is_small", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newIsAddrBoundedHint(ctx.operanders["addr"], ctx.operanders["ADDR_BOUND"], ctx.operanders["is_small"])
+				},
+				check: varValueEquals("is_small", feltUint64(1)),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "addr", Kind: apRelative, Value: feltString("3618502788666131106986593281521497120414687020801267626233049500247285300992")},
+					{Name: "ADDR_BOUND", Kind: immediate, Value: feltString("3618502788666131106986593281521497120414687020801267626233049500247285300992")},
+					{Name: "is_small", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newIsAddrBoundedHint(ctx.operanders["addr"], ctx.operanders["ADDR_BOUND"], ctx.operanders["is_small"])
+				},
+				check: varValueEquals("is_small", feltUint64(0)),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "addr", Kind: apRelative, Value: feltInt64(-1)},
+					{Name: "ADDR_BOUND", Kind: immediate, Value: feltString("3618502788666131106986593281521497120414687020801267626233049500247285300992")},
+					{Name: "is_small", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newIsAddrBoundedHint(ctx.operanders["addr"], ctx.operanders["ADDR_BOUND"], ctx.operanders["is_small"])
+				},
+				check: varValueEquals("is_small", feltUint64(0)),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "addr", Kind: apRelative, Value: feltUint64(1)},
+					{Name: "ADDR_BOUND", Kind: immediate, Value: &utils.FeltUpperBound},
+					{Name: "is_small", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newIsAddrBoundedHint(ctx.operanders["addr"], ctx.operanders["ADDR_BOUND"], ctx.operanders["is_small"])
+				},
+				errCheck: errorTextContains("normalize_address() cannot be used with the current constants"),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "addr", Kind: apRelative, Value: feltUint64(1)},
+					{Name: "ADDR_BOUND", Kind: immediate, Value: feltString("3618502788666131106986593281521497120414687020801267626233049500247285301249")},
+					{Name: "is_small", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newIsAddrBoundedHint(ctx.operanders["addr"], ctx.operanders["ADDR_BOUND"], ctx.operanders["is_small"])
+				},
+				errCheck: errorTextContains("normalize_address() cannot be used with the current constants"),
+			},
+		},
+		"Is250Bits": {
+			{
+				operanders: []*hintOperander{
+					{Name: "addr", Kind: apRelative, Value: feltString("1809251394333065553493296640760748560207343510400633813116524750123642650623")},
+					{Name: "is_250", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newIs250BitsHint(ctx.operanders["addr"], ctx.operanders["is_250"])
+				},
+				check: varValueEquals("is_250", feltUint64(1)),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "addr", Kind: apRelative, Value: &utils.FeltUpperBound},
+					{Name: "is_250", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newIs250BitsHint(ctx.operanders["addr"], ctx.operanders["is_250"])
+				},
+				check: varValueEquals("is_250", feltUint64(0)),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "addr", Kind: apRelative, Value: feltInt64(-1)},
+					{Name: "is_250", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newIs250BitsHint(ctx.operanders["addr"], ctx.operanders["is_250"])
+				},
+				check: varValueEquals("is_250", feltUint64(0)),
+			},
+		},
 		"IsNN": {
 			// is_nn would return 1 for non-negative values, but the
 			// hint itself writes 0 in this case (it's used as a jump condition).
@@ -307,6 +503,50 @@ func TestZeroHintMath(t *testing.T) {
 				check: apValueEquals(feltUint64(1)),
 			},
 		},
+		"IsNNWithFallback": {
+			// The fallback hint is only reached when the first one writes 1,
+			// in which case ap is advanced before executing it, hence the fp-based operander.
+			{
+				operanders: []*hintOperander{
+					// 2^128 + 5, above the bound: both hints fail
+					{Name: "a", Kind: fpRelative, Value: feltAdd(&utils.FeltMax128, feltInt64(5))},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return isNNWithFallback(ctx.operanders["a"])
+				},
+				check: isNNWithFallbackEquals(feltUint64(1), feltUint64(1)),
+			},
+			{
+				operanders: []*hintOperander{
+					// -5, a negative value: handled by the fallback
+					{Name: "a", Kind: fpRelative, Value: feltInt64(-5)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return isNNWithFallback(ctx.operanders["a"])
+				},
+				check: isNNWithFallbackEquals(feltUint64(1), feltUint64(0)),
+			},
+			{
+				operanders: []*hintOperander{
+					// -2^128, the lowest value handled by the fallback
+					{Name: "a", Kind: fpRelative, Value: new(fp.Element).Neg(&utils.FeltMax128)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return isNNWithFallback(ctx.operanders["a"])
+				},
+				check: isNNWithFallbackEquals(feltUint64(1), feltUint64(0)),
+			},
+			{
+				operanders: []*hintOperander{
+					// -2^128 - 1, below the fallback range
+					{Name: "a", Kind: fpRelative, Value: new(fp.Element).Neg(feltAdd(&utils.FeltMax128, feltInt64(1)))},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return isNNWithFallback(ctx.operanders["a"])
+				},
+				check: isNNWithFallbackEquals(feltUint64(1), feltUint64(1)),
+			},
+		},
 		"IsPositive": {
 			{
 				operanders: []*hintOperander{
@@ -450,7 +690,7 @@ func TestZeroHintMath(t *testing.T) {
 					{Name: "value", Kind: apRelative, Value: feltInt64(3042)},
 				},
 				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
-					return newAssert250bitsHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"])
+					return newAssert250bitsHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"], nil, nil)
 				},
 				check: allVarValueEquals(map[string]*fp.Element{
 					"low":  feltInt64(3042),
@@ -464,7 +704,7 @@ func TestZeroHintMath(t *testing.T) {
 					{Name: "value", Kind: fpRelative, Value: feltInt64(4938538853994)},
 				},
 				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
-					return newAssert250bitsHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"])
+					return newAssert250bitsHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"], nil, nil)
 				},
 				check: allVarValueEquals(map[string]*fp.Element{
 					"low":  feltInt64(4938538853994),
@@ -478,7 +718,7 @@ func TestZeroHintMath(t *testing.T) {
 					{Name: "value", Kind: apRelative, Value: feltString("348329493943842849393993999999231222222222")},
 				},
 				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
-					return newAssert250bitsHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"])
+					return newAssert250bitsHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"], nil, nil)
 				},
 				check: allVarValueEquals(map[string]*fp.Element{
 					"low":  feltString("220632583722801270961776596532341902734"),
@@ -492,7 +732,7 @@ func TestZeroHintMath(t *testing.T) {
 					{Name: "value", Kind: apRelative, Value: feltString("348329493943842849393124453993999999231222222222")},
 				},
 				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
-					return newAssert250bitsHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"])
+					return newAssert250bitsHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"], nil, nil)
 				},
 				check: allVarValueEquals(map[string]*fp.Element{
 					"low":  feltString("302658603189151847763334509038790380942"),
@@ -506,10 +746,38 @@ func TestZeroHintMath(t *testing.T) {
 					{Name: "value", Kind: apRelative, Value: feltInt64(-233)},
 				},
 				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
-					return newAssert250bitsHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"])
+					return newAssert250bitsHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"], nil, nil)
 				},
 				errCheck: errorTextContains("outside of the range [0, 2**250)"),
 			},
+			{
+				operanders: []*hintOperander{
+					{Name: "low", Kind: reference, Value: addrBuiltin(starknet.RangeCheck, 0)},
+					{Name: "high", Kind: reference, Value: addrBuiltin(starknet.RangeCheck, 1)},
+					{Name: "value", Kind: apRelative, Value: feltString("348329493943842849393993999999231222222222")},
+					{Name: "UPPER_BOUND", Kind: immediate, Value: feltString("1267650600228229401496703205376")},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newAssert250bitsHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"], ctx.operanders["UPPER_BOUND"], nil)
+				},
+				errCheck: errorTextContains("outside of the range [0, 1267650600228229401496703205376)"),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "low", Kind: reference, Value: addrBuiltin(starknet.RangeCheck, 0)},
+					{Name: "high", Kind: reference, Value: addrBuiltin(starknet.RangeCheck, 1)},
+					{Name: "value", Kind: apRelative, Value: feltString("1267650600228229401496703205375")},
+					{Name: "UPPER_BOUND", Kind: immediate, Value: feltString("1267650600228229401496703205376")},
+					{Name: "SHIFT", Kind: immediate, Value: feltString("18446744073709551616")},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newAssert250bitsHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"], ctx.operanders["UPPER_BOUND"], ctx.operanders["SHIFT"])
+				},
+				check: allVarValueEquals(map[string]*fp.Element{
+					"low":  feltString("18446744073709551615"),
+					"high": feltString("68719476735"),
+				}),
+			},
 		},
 		"Pow": {
 			{
@@ -544,7 +812,26 @@ func TestZeroHintMath(t *testing.T) {
 					"locs.bit": feltInt64(1),
 				}),
 			},
+			{
+				// PRIME - 2 is odd
+				operanders: []*hintOperander{
+					{Name: "prev_locs.bit", Kind: apRelative, Value: feltInt64(0)},
+					{Name: "prev_locs.temp0", Kind: apRelative, Value: feltInt64(0)},
+					{Name: "prev_locs.res", Kind: apRelative, Value: feltInt64(0)},
+					{Name: "prev_locs.base", Kind: apRelative, Value: feltInt64(0)},
+					{Name: "prev_locs.exp", Kind: apRelative, Value: feltInt64(-2)},
+					{Name: "locs.bit", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newPowHint(ctx.operanders["locs.bit"], ctx.operanders["prev_locs.bit"])
+				},
+				check: allVarValueEquals(map[string]*fp.Element{
+					"locs.bit": feltInt64(1),
+				}),
+			},
 		},
+		// 45 = 0b101101
+		"PowExponentBits": powExponentBitsTestCases(45, []int64{1, 0, 1, 1, 0, 1}),
 		"SignedPow": {
 			// Zero value - assertion failed, any other - nothing.
 			{
@@ -604,6 +891,33 @@ func TestZeroHintMath(t *testing.T) {
 					"high": feltInt64(1),
 				}),
 			},
+			{
+				// low and high are the range check cells proving the split
+				operanders: []*hintOperander{
+					{Name: "low", Kind: reference, Value: addrBuiltin(starknet.RangeCheck, 0)},
+					{Name: "high", Kind: reference, Value: addrBuiltin(starknet.RangeCheck, 1)},
+					{Name: "value", Kind: apRelative, Value: feltString("5784800237655953878877368326340059594760")},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSplitFeltHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"])
+				},
+				check: builtinSegmentValuesEqual(builtins.RangeCheckName, []*fp.Element{feltInt64(8), feltInt64(17)}),
+			},
+			{
+				// PRIME - 1 splits into MAX_LOW and MAX_HIGH, which both fit in the range check bound
+				operanders: []*hintOperander{
+					{Name: "low", Kind: reference, Value: addrBuiltin(starknet.RangeCheck, 0)},
+					{Name: "high", Kind: reference, Value: addrBuiltin(starknet.RangeCheck, 1)},
+					{Name: "value", Kind: apRelative, Value: feltInt64(-1)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSplitFeltHint(ctx.operanders["low"], ctx.operanders["high"], ctx.operanders["value"])
+				},
+				check: builtinSegmentValuesEqual(builtins.RangeCheckName, []*fp.Element{
+					feltInt64(0),
+					new(fp.Element).Div(feltInt64(-1), &utils.FeltMax128),
+				}),
+			},
 		},
 		"SignedDivRem": {
 			{
@@ -848,6 +1162,41 @@ func TestZeroHintMath(t *testing.T) {
 			},
 		},
 
+		"GetFeltBitLength": {
+			{
+				// 0
+				operanders: []*hintOperander{
+					{Name: "x", Kind: apRelative, Value: feltUint64(0)},
+					{Name: "bit_length", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newGetFeltBitLengthHint(ctx.operanders["x"], ctx.operanders["bit_length"])
+				},
+				check: varValueEquals("bit_length", feltUint64(0)),
+			},
+			{
+				// 2**40
+				operanders: []*hintOperander{
+					{Name: "x", Kind: apRelative, Value: feltUint64(1 << 40)},
+					{Name: "bit_length", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newGetFeltBitLengthHint(ctx.operanders["x"], ctx.operanders["bit_length"])
+				},
+				check: varValueEquals("bit_length", feltUint64(41)),
+			},
+			{
+				// PRIME - 1 = 2**251 + 17 * 2**192
+				operanders: []*hintOperander{
+					{Name: "x", Kind: apRelative, Value: feltInt64(-1)},
+					{Name: "bit_length", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newGetFeltBitLengthHint(ctx.operanders["x"], ctx.operanders["bit_length"])
+				},
+				check: varValueEquals("bit_length", feltUint64(252)),
+			},
+		},
 		"IsQuadResidue": {
 			// Test case: x is 0
 			{
@@ -901,6 +1250,89 @@ func TestZeroHintMath(t *testing.T) {
 				},
 				check: varValueEquals("y", feltString("1484343478756640997457155271309092907848857951878936388435701743478603286656")),
 			},
+			// Test case: x is not a quadratic residue, and the smallest root of x / 3 is written
+			{
+				operanders: []*hintOperander{
+					{Name: "y", Kind: uninitialized},
+					{Name: "x", Kind: fpRelative, Value: feltInt64(27)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newIsQuadResidueHint(ctx.operanders["x"], ctx.operanders["y"])
+				},
+				check: varValueEquals("y", feltInt64(3)),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "y", Kind: uninitialized},
+					{Name: "x", Kind: fpRelative, Value: feltInt64(21)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newIsQuadResidueHint(ctx.operanders["x"], ctx.operanders["y"])
+				},
+				check: varValueEquals("y", feltString("209880913220488156313834499058596184255382000503803956865172539718143124843")),
+			},
 		},
 	})
 }
+
+// isNNWithFallback mimics `is_nn` Cairo function control flow, which executes
+// the IsNNOutOfRange hint one step after IsNN when the latter writes 1
+func isNNWithFallback(a hinter.ResOperander) hinter.Hinter {
+	return &GenericZeroHinter{
+		Name: "IsNNWithFallback",
+		Op: func(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+			if err := newIsNNHint(a).Execute(vm, ctx); err != nil {
+				return err
+			}
+			apAddr := vm.Context.AddressAp()
+			v, err := vm.Memory.ReadFromAddressAsElement(&apAddr)
+			if err != nil {
+				return err
+			}
+			if v.IsZero() {
+				return nil
+			}
+			vm.Context.Ap++
+			return newIsNNOutOfRangeHint(a).Execute(vm, ctx)
+		},
+	}
+}
+
+func isNNWithFallbackEquals(isNN, outOfRange *fp.Element) func(t *testing.T, ctx *hintTestContext) {
+	return func(t *testing.T, ctx *hintTestContext) {
+		apAddr := ctx.vm.Context.AddressAp()
+		isNNAddr, err := apAddr.AddOffset(-1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		valueAtAddressEquals(isNNAddr, isNN)(t, ctx)
+		apValueEquals(outOfRange)(t, ctx)
+	}
+}
+
+// powExponentBitsTestCases mimics the `pow` Cairo function loop, where each
+// iteration halves the exponent, and expects the Pow hint to produce `bits`
+// (least significant first)
+func powExponentBitsTestCases(exp uint64, bits []int64) []hintTestCase {
+	testCases := make([]hintTestCase, 0, len(bits))
+	for i, bit := range bits {
+		testCases = append(testCases, hintTestCase{
+			// The other fields are odd to catch reads of the wrong struct cell.
+			operanders: []*hintOperander{
+				{Name: "prev_locs.bit", Kind: apRelative, Value: feltInt64(7)},
+				{Name: "prev_locs.temp0", Kind: apRelative, Value: feltInt64(7)},
+				{Name: "prev_locs.res", Kind: apRelative, Value: feltInt64(7)},
+				{Name: "prev_locs.base", Kind: apRelative, Value: feltInt64(7)},
+				{Name: "prev_locs.exp", Kind: apRelative, Value: feltUint64(exp >> i)},
+				{Name: "locs.bit", Kind: uninitialized},
+			},
+			makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+				return newPowHint(ctx.operanders["locs.bit"], ctx.operanders["prev_locs.bit"])
+			},
+			check: allVarValueEquals(map[string]*fp.Element{
+				"locs.bit": feltInt64(bit),
+			}),
+		})
+	}
+	return testCases
+}
@@ -2,7 +2,7 @@ package zero
 
 import (
 	"fmt"
-	"reflect"
+	"regexp"
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/core"
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/hinter"
@@ -157,7 +157,7 @@ func createMemEnterScopeHinter(resolver hintReferenceResolver, memset bool) (hin
 // `newFindElementHint` takes 5 operanders as arguments
 //   - `arrayPtr` is the pointer to the base of the array in memory
 //   - `elmSize` is the size of the element in the array (the number of memory cells that the element occupies)
-//   - `key` is the felt key to search for in the array
+//   - `key` is the felt key to search for in the array, compared against the first memory cell of each element
 //   - `index` is the address in memory where to write the index of the found element in the array
 //   - `nElms` is the number of elements in the array
 func newFindElementHint(arrayPtr, elmSize, key, index, nElms hinter.ResOperander) hinter.Hinter {
@@ -273,15 +273,8 @@ func newFindElementHint(arrayPtr, elmSize, key, index, nElms hinter.ResOperander
 				//>			assert n_elms <= __find_element_max_size, \
 				//>				f'find_element() can only be used with n_elms<={__find_element_max_size}. ' \
 				//>				f'Got: n_elms={n_elms}.'
-				findElementMaxSize, err := ctx.ScopeManager.GetVariableValue("__find_element_max_size")
-				if err == nil {
-					findElementMaxSize, ok := findElementMaxSize.(uint64)
-					if !ok {
-						return fmt.Errorf("invalid value for __find_element_max_size. Got: %v", findElementMaxSize)
-					}
-					if nElms > findElementMaxSize {
-						return fmt.Errorf("find_element() can only be used with n_elms<=%v. Got: n_elms=%v", findElementMaxSize, nElms)
-					}
+				if err := checkFindElementMaxSize(ctx, nElms); err != nil {
+					return err
 				}
 
 				//>		for i in range(n_elms):
@@ -395,14 +388,9 @@ func newSetAddHint(elmSize, elmPtr, setPtr, setEndPtr, index, isElmInSet hinter.
 			}
 
 			//> assert ids.set_ptr <= ids.set_end_ptr
-			if setPtr.Offset > setEndPtr.Offset {
-				return fmt.Errorf("assert ids.set_ptr <= ids.set_end_ptr failed")
-			}
-
-			//> elm_list = memory.get_range(ids.elm_ptr, ids.elm_size)
-			elmList, err := vm.Memory.GetConsecutiveMemoryValues(*elmPtr, int16(elmSize))
+			totalSetLength, err := setEndPtr.SubAddress(setPtr)
 			if err != nil {
-				return err
+				return fmt.Errorf("assert ids.set_ptr <= ids.set_end_ptr failed: %w", err)
 			}
 
 			//> for i in range(0, ids.set_end_ptr - ids.set_ptr, ids.elm_size):
@@ -413,17 +401,13 @@ func newSetAddHint(elmSize, elmPtr, setPtr, setEndPtr, index, isElmInSet hinter.
 			//>     else:
 			//>         ids.is_elm_in_set = 0
 			isElmInSetFelt := utils.FeltZero
-			totalSetLength := setEndPtr.Offset - setPtr.Offset
 			for i := uint64(0); i < totalSetLength; i += elmSize {
-				memoryElmList, err := vm.Memory.GetConsecutiveMemoryValues(*setPtr, int16(elmSize))
+				setElmPtr := memory.MemoryAddress{SegmentIndex: setPtr.SegmentIndex, Offset: setPtr.Offset + i}
+				equal, err := hinter.MemEqualRange(vm, setElmPtr, *elmPtr, elmSize)
 				if err != nil {
 					return err
 				}
-				*setPtr, err = setPtr.AddOffset(int16(elmSize))
-				if err != nil {
-					return err
-				}
-				if reflect.DeepEqual(memoryElmList, elmList) {
+				if equal {
 					indexFelt := fp.NewElement(i / elmSize)
 					indexMv := memory.MemoryValueFromFieldElement(&indexFelt)
 					err := vm.Memory.WriteToAddress(&indexAddr, &indexMv)
@@ -470,6 +454,24 @@ func createSetAddHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
 	return newSetAddHint(elmSize, elmPtr, setPtr, setEndPtr, index, isElmInSet), nil
 }
 
+// checkFindElementMaxSize enforces the optional `__find_element_max_size` scope
+// variable shared by the find_element and search_sorted hints. There is no limit
+// when it isn't in scope
+func checkFindElementMaxSize(ctx *hinter.HintRunnerContext, nElms uint64) error {
+	findElementMaxSize, err := ctx.ScopeManager.GetVariableValue("__find_element_max_size")
+	if err != nil {
+		return nil
+	}
+	maxSize, ok := findElementMaxSize.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid value for __find_element_max_size. Got: %v", findElementMaxSize)
+	}
+	if nElms > maxSize {
+		return fmt.Errorf("find_element() can only be used with n_elms<=%d. Got: n_elms=%d", maxSize, nElms)
+	}
+	return nil
+}
+
 // SearchSortedLower hint searches for the first element in a sorted array
 // that is greater than or equal to a given key and returns its index
 //
@@ -533,9 +535,8 @@ func newSearchSortedLowerHint(arrayPtr, elmSize, nElms, key, index hinter.ResOpe
 			//> 	assert n_elms <= __find_element_max_size, \
 			//> 		f'find_element() can only be used with n_elms<={__find_element_max_size}. ' \
 			//> 		f'Got: n_elms={n_elms}.'
-			elementMaxSize := uint64(1 << 20)
-			if nElms > elementMaxSize {
-				return fmt.Errorf("find_element() can only be used with n_elms<=%d.\n Got: length=%d", elementMaxSize, nElms)
+			if err := checkFindElementMaxSize(ctx, nElms); err != nil {
+				return err
 			}
 
 			key, err := hinter.ResolveAsFelt(vm, key)
@@ -692,3 +693,171 @@ func createNondetElementsOverTenHinter(resolver hintReferenceResolver) (hinter.H
 
 	return newNondetElementsOverTenHint(n), nil
 }
+
+// assertEqualCodeRegexp matches the simple `assert ids.a == ids.b` hints, which
+// are handled by a single generic hint instead of one hint per code
+var assertEqualCodeRegexp = regexp.MustCompile(`^assert ids\.(\w+) == ids\.(\w+)$`)
+
+// assertEqualOperands returns the names of the two variables compared by an
+// equality assertion hint, or nil if `code` isn't one
+func assertEqualOperands(code string) []string {
+	matches := assertEqualCodeRegexp.FindStringSubmatch(code)
+	if matches == nil {
+		return nil
+	}
+	return matches[1:]
+}
+
+// AssertEqual hint asserts that two variables hold the same value
+//
+// `newAssertEqualHint` takes 3 arguments
+//   - `code` is the hint code, used in the error message
+//   - `a` and `b` are the variables to compare, either both felts or both addresses
+func newAssertEqualHint(code string, a, b hinter.ResOperander) hinter.Hinter {
+	return &GenericZeroHinter{
+		Name:     "AssertEqual",
+		Operands: assertEqualOperands(code),
+		Op: func(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+			//> assert ids.a == ids.b
+
+			aValue, err := a.Resolve(vm)
+			if err != nil {
+				return err
+			}
+
+			bValue, err := b.Resolve(vm)
+			if err != nil {
+				return err
+			}
+
+			if !aValue.Equal(&bValue) {
+				return fmt.Errorf("`%s` failed: %s != %s", code, aValue, bValue)
+			}
+			return nil
+		},
+	}
+}
+
+func createAssertEqualHinter(resolver hintReferenceResolver, code string) (hinter.Hinter, error) {
+	operands := assertEqualOperands(code)
+	if operands == nil {
+		return nil, fmt.Errorf("not an equality assertion hint: %s", code)
+	}
+
+	a, err := resolver.GetResOperander(operands[0])
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := resolver.GetResOperander(operands[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return newAssertEqualHint(code, a, b), nil
+}
+
+// LoadProgram hint loads the data of a bootloader task, i.e. its program header followed
+// by its bytecode, into memory and sets up the scope for the nested run of the task
+//
+// `newLoadProgramHint` takes 1 operander as argument
+//   - `programHeader` is the address where the program data is loaded
+//
+// The program data is read from the address held by the `program_data_ptr` scope
+// variable, where the runner puts it before running the bootloader. It starts with
+// the `ProgramHeader` fields: `data_length`, `bootloader_version`, `program_main`,
+// `n_builtins` and the `n_builtins` builtins, followed by the bytecode. As in the
+// bootloader, `data_length` counts every cell of the program data but itself
+//
+// `newLoadProgramHint` copies the program data at `programHeader`, finalizes the segment
+// of `program_data_ptr` at the end of the program data and assigns in the current scope
+// `program_address`, the address of the first bytecode cell, and `program_data_size`,
+// the number of cells loaded
+func newLoadProgramHint(programHeader hinter.ResOperander) hinter.Hinter {
+	return &GenericZeroHinter{
+		Name:     "LoadProgram",
+		Operands: []string{"program_header"},
+		Op: func(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+			//> from starkware.cairo.bootloaders.simple_bootloader.utils import load_program
+			//>
+			//> # Call load_program to load the program header and code to memory.
+			//> program_address, program_data_size = load_program(
+			//>     task=task, memory=memory, program_header=ids.program_header,
+			//>     builtins_offset=ids.ProgramHeader.builtin_list)
+			//> segments.finalize(program_data_ptr.segment_index, program_data_size)
+
+			programDataPtrValue, err := ctx.ScopeManager.GetVariableValue("program_data_ptr")
+			if err != nil {
+				return err
+			}
+			programDataPtr, ok := programDataPtrValue.(memory.MemoryAddress)
+			if !ok {
+				return fmt.Errorf("casting program_data_ptr into a memory.MemoryAddress failed")
+			}
+
+			const nBuiltinsOffset = 3
+			var header [nBuiltinsOffset + 1]uint64
+			for i := range header {
+				fieldMv, err := vm.Memory.Peek(programDataPtr.SegmentIndex, programDataPtr.Offset+uint64(i))
+				if err != nil {
+					return err
+				}
+				if !fieldMv.Known() {
+					return fmt.Errorf("program data is truncated: its header is missing field %d", i)
+				}
+				field, err := fieldMv.FieldElement()
+				if err != nil {
+					return fmt.Errorf("program header field %d: %w", i, err)
+				}
+				if !field.IsUint64() {
+					return fmt.Errorf("program header field %d is too big: %v", i, field)
+				}
+				header[i] = field.Uint64()
+			}
+			// `data_length` doesn't count itself
+			programDataSize := header[0] + 1
+			headerSize := nBuiltinsOffset + 1 + header[nBuiltinsOffset]
+			if header[nBuiltinsOffset] > programDataSize || headerSize > programDataSize {
+				return fmt.Errorf("program header with %d builtins doesn't fit in %d cells", header[nBuiltinsOffset], programDataSize)
+			}
+
+			programHeaderAddr, err := programHeader.GetAddress(vm)
+			if err != nil {
+				return err
+			}
+			for i := uint64(0); i < programDataSize; i++ {
+				cellMv, err := vm.Memory.Peek(programDataPtr.SegmentIndex, programDataPtr.Offset+i)
+				if err != nil {
+					return err
+				}
+				if !cellMv.Known() {
+					return fmt.Errorf("program data is truncated: its header holds %d cells but only %d are set", programDataSize, i)
+				}
+				if err := vm.Memory.Write(programHeaderAddr.SegmentIndex, programHeaderAddr.Offset+i, &cellMv); err != nil {
+					return err
+				}
+			}
+
+			vm.Memory.Segments[programDataPtr.SegmentIndex].Finalize(programDataPtr.Offset + programDataSize)
+
+			programAddress := memory.MemoryAddress{
+				SegmentIndex: programHeaderAddr.SegmentIndex,
+				Offset:       programHeaderAddr.Offset + headerSize,
+			}
+
+			return ctx.ScopeManager.AssignVariables(map[string]any{
+				"program_address":   programAddress,
+				"program_data_size": programDataSize,
+			})
+		},
+	}
+}
+
+func createLoadProgramHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
+	programHeader, err := resolver.GetResOperander("program_header")
+	if err != nil {
+		return nil, err
+	}
+
+	return newLoadProgramHint(programHeader), nil
+}
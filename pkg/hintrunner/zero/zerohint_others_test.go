@@ -5,7 +5,10 @@ import (
 	"testing"
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/hinter"
+	VM "github.com/NethermindEth/cairo-vm-go/pkg/vm"
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/require"
 )
 
 func TestZeroHintOthers(t *testing.T) {
@@ -48,6 +51,104 @@ func TestZeroHintOthers(t *testing.T) {
 				},
 			},
 		},
+		"LoadProgram": {
+			{
+				vmInit: func(vm *VM.VirtualMachine) {
+					// a header with the output builtin, then `[ap] = 2, ap++; ret;`
+					_, err := vm.Memory.AllocateSegment([]*fp.Element{
+						feltUint64(7), feltUint64(0), feltUint64(0), feltUint64(1), feltString("0x6f7574707574"),
+						feltString("0x480680017fff8000"), feltUint64(2), feltString("0x208b7fff7fff7ffe"),
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("program_data_ptr", *addrWithSegment(2, 0))
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				operanders: []*hintOperander{
+					{Name: "program_header", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newLoadProgramHint(ctx.operanders["program_header"])
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					consecutiveVarValueEquals("program_header", []*fp.Element{
+						feltUint64(7), feltUint64(0), feltUint64(0), feltUint64(1), feltString("0x6f7574707574"),
+						feltString("0x480680017fff8000"), feltUint64(2), feltString("0x208b7fff7fff7ffe"),
+					})(t, ctx)
+					varValueInScopeEquals("program_data_size", uint64(8))(t, ctx)
+					require.Equal(t, uint64(8), ctx.vm.Memory.Segments[2].Len())
+
+					programHeader, err := ctx.operanders["program_header"].GetAddress(ctx.vm)
+					require.NoError(t, err)
+					programAddress, err := ctx.runnerContext.ScopeManager.GetVariableValue("program_address")
+					require.NoError(t, err)
+					require.Equal(t, memory.MemoryAddress{SegmentIndex: programHeader.SegmentIndex, Offset: programHeader.Offset + 5}, programAddress)
+				},
+			},
+			{
+				vmInit: func(vm *VM.VirtualMachine) {
+					// the header claims 3 builtins but the program data holds 5 cells
+					_, err := vm.Memory.AllocateSegment([]*fp.Element{
+						feltUint64(4), feltUint64(0), feltUint64(0), feltUint64(3), feltString("0x6f7574707574"),
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("program_data_ptr", *addrWithSegment(2, 0))
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				operanders: []*hintOperander{
+					{Name: "program_header", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newLoadProgramHint(ctx.operanders["program_header"])
+				},
+				errCheck: errorTextContains("program header with 3 builtins doesn't fit in 5 cells"),
+			},
+			{
+				vmInit: func(vm *VM.VirtualMachine) {
+					// the header holds 8 cells but the bytecode is cut after its first cell
+					_, err := vm.Memory.AllocateSegment([]*fp.Element{
+						feltUint64(7), feltUint64(0), feltUint64(0), feltUint64(1), feltString("0x6f7574707574"),
+						feltString("0x480680017fff8000"),
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("program_data_ptr", *addrWithSegment(2, 0))
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				operanders: []*hintOperander{
+					{Name: "program_header", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newLoadProgramHint(ctx.operanders["program_header"])
+				},
+				errCheck: errorTextContains("program data is truncated: its header holds 8 cells but only 6 are set"),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "program_header", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newLoadProgramHint(ctx.operanders["program_header"])
+				},
+				errCheck: errorTextContains("variable program_data_ptr not found"),
+			},
+		},
 		"MemcpyEnterScope": {
 			{
 				operanders: []*hintOperander{
@@ -60,6 +161,60 @@ func TestZeroHintOthers(t *testing.T) {
 			},
 		},
 		"SearchSortedLower": {
+			{
+				operanders: []*hintOperander{
+					{Name: "array_ptr", Kind: fpRelative, Value: addr(8)},
+					{Name: "elm_size", Kind: fpRelative, Value: feltInt64(1)},
+					{Name: "n_elms", Kind: fpRelative, Value: feltInt64(2)},
+					{Name: "key", Kind: fpRelative, Value: feltInt64(2)},
+					{Name: "array.0", Kind: apRelative, Value: feltInt64(1)},
+					{Name: "array.1", Kind: apRelative, Value: feltInt64(3)},
+					{Name: "index", Kind: uninitialized},
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("__find_element_max_size", uint64(2))
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSearchSortedLowerHint(
+						ctx.operanders["array_ptr"],
+						ctx.operanders["elm_size"],
+						ctx.operanders["n_elms"],
+						ctx.operanders["key"],
+						ctx.operanders["index"],
+					)
+				},
+				check: varValueEquals("index", feltInt64(1)),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "array_ptr", Kind: fpRelative, Value: addr(8)},
+					{Name: "elm_size", Kind: fpRelative, Value: feltInt64(1)},
+					{Name: "n_elms", Kind: fpRelative, Value: feltInt64(2)},
+					{Name: "key", Kind: fpRelative, Value: feltInt64(2)},
+					{Name: "array.0", Kind: apRelative, Value: feltInt64(1)},
+					{Name: "array.1", Kind: apRelative, Value: feltInt64(3)},
+					{Name: "index", Kind: uninitialized},
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("__find_element_max_size", uint64(1))
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSearchSortedLowerHint(
+						ctx.operanders["array_ptr"],
+						ctx.operanders["elm_size"],
+						ctx.operanders["n_elms"],
+						ctx.operanders["key"],
+						ctx.operanders["index"],
+					)
+				},
+				errCheck: errorTextContains("find_element() can only be used with n_elms<=1. Got: n_elms=2"),
+			},
 			{
 				operanders: []*hintOperander{
 					{Name: "array_ptr", Kind: fpRelative, Value: addr(7)},
@@ -299,6 +454,50 @@ func TestZeroHintOthers(t *testing.T) {
 					varValueNotInScope("__find_element_index")(t, ctx)
 				},
 			},
+			{
+				// the override is used as is, the array is not scanned and n_elms is never read
+				operanders: []*hintOperander{
+					{Name: "array_ptr", Kind: apRelative, Value: addr(9)},
+					{Name: "elm_size", Kind: apRelative, Value: feltUint64(2)},
+					{Name: "key", Kind: apRelative, Value: feltUint64(5)},
+					{Name: "index", Kind: uninitialized},
+					{Name: "n_elms", Kind: uninitialized},
+					{Name: "array.0.key", Kind: apRelative, Value: feltUint64(5)},
+					{Name: "array.0.value", Kind: apRelative, Value: feltUint64(1)},
+					{Name: "array.1.key", Kind: apRelative, Value: feltUint64(5)},
+					{Name: "array.1.value", Kind: apRelative, Value: feltUint64(2)},
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("__find_element_index", uint64(1))
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newFindElementHint(ctx.operanders["array_ptr"], ctx.operanders["elm_size"], ctx.operanders["key"], ctx.operanders["index"], ctx.operanders["n_elms"])
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					varValueEquals("index", feltUint64(1))(t, ctx)
+					varValueNotInScope("__find_element_index")(t, ctx)
+				},
+			},
+			{
+				// without any override, the array is scanned for the key
+				operanders: []*hintOperander{
+					{Name: "array_ptr", Kind: apRelative, Value: addr(9)},
+					{Name: "elm_size", Kind: apRelative, Value: feltUint64(1)},
+					{Name: "key", Kind: apRelative, Value: feltUint64(200)},
+					{Name: "index", Kind: uninitialized},
+					{Name: "n_elms", Kind: apRelative, Value: feltUint64(3)},
+					{Name: "array.0", Kind: apRelative, Value: feltUint64(100)},
+					{Name: "array.1", Kind: apRelative, Value: feltUint64(200)},
+					{Name: "array.2", Kind: apRelative, Value: feltUint64(300)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newFindElementHint(ctx.operanders["array_ptr"], ctx.operanders["elm_size"], ctx.operanders["key"], ctx.operanders["index"], ctx.operanders["n_elms"])
+				},
+				check: varValueEquals("index", feltUint64(1)),
+			},
 			{
 				operanders: []*hintOperander{
 					{Name: "array_ptr", Kind: apRelative, Value: addr(7)},
@@ -365,6 +564,60 @@ func TestZeroHintOthers(t *testing.T) {
 				},
 				errCheck: errorTextContains(fmt.Sprintf("key %v was not found", feltUint64(999))),
 			},
+			{
+				// only the key cell of each element is compared, so the first of two elements
+				// sharing a key is found even though their second cells differ
+				operanders: []*hintOperander{
+					{Name: "array_ptr", Kind: apRelative, Value: addr(9)},
+					{Name: "elm_size", Kind: apRelative, Value: feltUint64(2)},
+					{Name: "key", Kind: apRelative, Value: feltUint64(5)},
+					{Name: "index", Kind: uninitialized},
+					{Name: "n_elms", Kind: apRelative, Value: feltUint64(2)},
+					{Name: "array.0.key", Kind: apRelative, Value: feltUint64(5)},
+					{Name: "array.0.value", Kind: apRelative, Value: feltUint64(7)},
+					{Name: "array.1.key", Kind: apRelative, Value: feltUint64(5)},
+					{Name: "array.1.value", Kind: apRelative, Value: feltUint64(9)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newFindElementHint(ctx.operanders["array_ptr"], ctx.operanders["elm_size"], ctx.operanders["key"], ctx.operanders["index"], ctx.operanders["n_elms"])
+				},
+				check: varValueEquals("index", feltUint64(0)),
+			},
+			{
+				// the search steps over whole elements, so a key equal to a second cell is skipped
+				operanders: []*hintOperander{
+					{Name: "array_ptr", Kind: apRelative, Value: addr(9)},
+					{Name: "elm_size", Kind: apRelative, Value: feltUint64(2)},
+					{Name: "key", Kind: apRelative, Value: feltUint64(9)},
+					{Name: "index", Kind: uninitialized},
+					{Name: "n_elms", Kind: apRelative, Value: feltUint64(2)},
+					{Name: "array.0.key", Kind: apRelative, Value: feltUint64(5)},
+					{Name: "array.0.value", Kind: apRelative, Value: feltUint64(9)},
+					{Name: "array.1.key", Kind: apRelative, Value: feltUint64(9)},
+					{Name: "array.1.value", Kind: apRelative, Value: feltUint64(5)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newFindElementHint(ctx.operanders["array_ptr"], ctx.operanders["elm_size"], ctx.operanders["key"], ctx.operanders["index"], ctx.operanders["n_elms"])
+				},
+				check: varValueEquals("index", feltUint64(1)),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "array_ptr", Kind: apRelative, Value: addr(9)},
+					{Name: "elm_size", Kind: apRelative, Value: feltUint64(2)},
+					{Name: "key", Kind: apRelative, Value: feltUint64(7)},
+					{Name: "index", Kind: uninitialized},
+					{Name: "n_elms", Kind: apRelative, Value: feltUint64(2)},
+					{Name: "array.0.key", Kind: apRelative, Value: feltUint64(5)},
+					{Name: "array.0.value", Kind: apRelative, Value: feltUint64(7)},
+					{Name: "array.1.key", Kind: apRelative, Value: feltUint64(6)},
+					{Name: "array.1.value", Kind: apRelative, Value: feltUint64(7)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newFindElementHint(ctx.operanders["array_ptr"], ctx.operanders["elm_size"], ctx.operanders["key"], ctx.operanders["index"], ctx.operanders["n_elms"])
+				},
+				errCheck: errorTextContains(fmt.Sprintf("key %v was not found", feltUint64(7))),
+			},
 		},
 		"SetAdd": {
 			{
@@ -409,6 +662,27 @@ func TestZeroHintOthers(t *testing.T) {
 				},
 				errCheck: errorTextContains("assert ids.set_ptr <= ids.set_end_ptr failed"),
 			},
+			{
+				operanders: []*hintOperander{
+					{Name: "elm_size", Kind: apRelative, Value: feltUint64(1)},
+					{Name: "elm_ptr", Kind: apRelative, Value: addrWithSegment(1, 0)},
+					{Name: "set_ptr", Kind: apRelative, Value: addrWithSegment(1, 0)},
+					{Name: "set_end_ptr", Kind: apRelative, Value: addrWithSegment(2, 4)},
+					{Name: "index", Kind: uninitialized},
+					{Name: "is_elm_in_set", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSetAddHint(
+						ctx.operanders["elm_size"],
+						ctx.operanders["elm_ptr"],
+						ctx.operanders["set_ptr"],
+						ctx.operanders["set_end_ptr"],
+						ctx.operanders["index"],
+						ctx.operanders["is_elm_in_set"],
+					)
+				},
+				errCheck: errorTextContains("cannot subtract addresses from different segments: 2 != 1"),
+			},
 			{
 				operanders: []*hintOperander{
 					{Name: "elm.1", Kind: apRelative, Value: feltUint64(1)},
@@ -579,5 +853,57 @@ func TestZeroHintOthers(t *testing.T) {
 				check: apValueEquals(feltUint64(1)),
 			},
 		},
+		"AssertEqual": {
+			{
+				operanders: []*hintOperander{
+					{Name: "a", Kind: apRelative, Value: feltUint64(42)},
+					{Name: "b", Kind: fpRelative, Value: feltUint64(42)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newAssertEqualHint("assert ids.a == ids.b", ctx.operanders["a"], ctx.operanders["b"])
+				},
+				errCheck: errorIsNil,
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "a", Kind: apRelative, Value: addrWithSegment(2, 3)},
+					{Name: "b", Kind: fpRelative, Value: addrWithSegment(2, 3)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newAssertEqualHint("assert ids.a == ids.b", ctx.operanders["a"], ctx.operanders["b"])
+				},
+				errCheck: errorIsNil,
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "a", Kind: apRelative, Value: feltUint64(42)},
+					{Name: "b", Kind: fpRelative, Value: feltInt64(-42)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newAssertEqualHint("assert ids.a == ids.b", ctx.operanders["a"], ctx.operanders["b"])
+				},
+				errCheck: errorTextContains("`assert ids.a == ids.b` failed: 42 != -42"),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "a", Kind: apRelative, Value: addrWithSegment(2, 3)},
+					{Name: "b", Kind: fpRelative, Value: addrWithSegment(2, 4)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newAssertEqualHint("assert ids.a == ids.b", ctx.operanders["a"], ctx.operanders["b"])
+				},
+				errCheck: errorTextContains("`assert ids.a == ids.b` failed: 2:3 != 2:4"),
+			},
+			{
+				operanders: []*hintOperander{
+					{Name: "a", Kind: apRelative, Value: addrWithSegment(2, 3)},
+					{Name: "b", Kind: fpRelative, Value: feltUint64(3)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newAssertEqualHint("assert ids.a == ids.b", ctx.operanders["a"], ctx.operanders["b"])
+				},
+				errCheck: errorTextContains("`assert ids.a == ids.b` failed: 2:3 != 3"),
+			},
+		},
 	})
 }
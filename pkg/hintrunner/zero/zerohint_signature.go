@@ -31,50 +31,63 @@ func newVerifyZeroHint(val, q hinter.ResOperander) hinter.Hinter {
 			//> assert r == 0, f"verify_zero: Invalid input {ids.val.d0, ids.val.d1, ids.val.d2}."
 			//> ids.q = q % PRIME
 
-			valAddr, err := val.GetAddress(vm)
-			if err != nil {
-				return err
-			}
-
-			valValues, err := vm.Memory.ResolveAsBigInt3(valAddr)
-			if err != nil {
-				return err
-			}
-
 			//> from starkware.cairo.common.cairo_secp.secp_utils import SECP_P, pack
 			secPBig, ok := secp_utils.GetSecPBig()
 			if !ok {
 				return fmt.Errorf("GetSecPBig failed")
 			}
 
-			//> q, r = divmod(pack(ids.val, PRIME), SECP_P)
-			packedValue, err := secp_utils.SecPPacked(valValues)
-			if err != nil {
-				return err
-			}
-			qBig, rBig := new(big.Int), new(big.Int)
-			qBig.DivMod(&packedValue, &secPBig, rBig)
+			return verifyZero(vm, val, q, &secPBig)
+		},
+	}
+}
 
-			//> assert r == 0, f"verify_zero: Invalid input {ids.val.d0, ids.val.d1, ids.val.d2}."
-			if rBig.Cmp(big.NewInt(0)) != 0 {
-				return fmt.Errorf("verify_zero: Invalid input (%v, %v, %v)", valValues[0], valValues[1], valValues[2])
-			}
+func createVerifyZeroHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
+	val, err := resolver.GetResOperander("val")
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := resolver.GetResOperander("q")
+	if err != nil {
+		return nil, err
+	}
+
+	return newVerifyZeroHint(val, q), nil
+}
 
+// VerifyZeroMod hint verifies that a packed value is zero modulo the `SECP_P` prime
+// found in the current scope, which allows using it for any curve such as secp256r1,
+// and stores in memory the quotient of the modular divison of the packed value by `SECP_P`
+//
+// `newVerifyZeroModHint` takes 2 operanders as arguments
+//   - `value` is the value that will be verified
+//   - `q` is the variable that will store the quotient of the modular division
+//
+// `newVerifyZeroModHint` writes the quotient of the modular division of the packed value
+// by `SECP_P` to the memory address corresponding to `q`
+func newVerifyZeroModHint(val, q hinter.ResOperander) hinter.Hinter {
+	return &GenericZeroHinter{
+		Name:     "VerifyZeroMod",
+		Operands: []string{"val", "q"},
+		Op: func(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+			//> from starkware.cairo.common.cairo_secp.secp_utils import pack
+			//>
+			//> q, r = divmod(pack(ids.val, PRIME), SECP_P)
+			//> assert r == 0, f"verify_zero: Invalid input {ids.val.d0, ids.val.d1, ids.val.d2}."
 			//> ids.q = q % PRIME
-			qBig.Mod(qBig, fp.Modulus())
-			qFelt := new(fp.Element).SetBigInt(qBig)
-			qAddr, err := q.GetAddress(vm)
+
+			secPBig, err := ctx.ScopeManager.GetVariableValueAsBigInt("SECP_P")
 			if err != nil {
 				return err
 			}
 
-			qMv := mem.MemoryValueFromFieldElement(qFelt)
-			return vm.Memory.WriteToAddress(&qAddr, &qMv)
+			return verifyZero(vm, val, q, secPBig)
 		},
 	}
 }
 
-func createVerifyZeroHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
+func createVerifyZeroModHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
 	val, err := resolver.GetResOperander("val")
 	if err != nil {
 		return nil, err
@@ -85,7 +98,45 @@ func createVerifyZeroHinter(resolver hintReferenceResolver) (hinter.Hinter, erro
 		return nil, err
 	}
 
-	return newVerifyZeroHint(val, q), nil
+	return newVerifyZeroModHint(val, q), nil
+}
+
+// verifyZero checks that the packed value `val` is a multiple of `secPBig`
+// and writes the quotient to `q`
+func verifyZero(vm *VM.VirtualMachine, val, q hinter.ResOperander, secPBig *big.Int) error {
+	valAddr, err := val.GetAddress(vm)
+	if err != nil {
+		return err
+	}
+
+	valValues, err := vm.Memory.ResolveAsBigInt3(valAddr)
+	if err != nil {
+		return err
+	}
+
+	//> q, r = divmod(pack(ids.val, PRIME), SECP_P)
+	packedValue, err := secp_utils.SecPPacked(valValues)
+	if err != nil {
+		return err
+	}
+	qBig, rBig := new(big.Int), new(big.Int)
+	qBig.DivMod(&packedValue, secPBig, rBig)
+
+	//> assert r == 0, f"verify_zero: Invalid input {ids.val.d0, ids.val.d1, ids.val.d2}."
+	if rBig.Cmp(big.NewInt(0)) != 0 {
+		return fmt.Errorf("verify_zero: Invalid input (%v, %v, %v)", valValues[0], valValues[1], valValues[2])
+	}
+
+	//> ids.q = q % PRIME
+	qBig.Mod(qBig, fp.Modulus())
+	qFelt := new(fp.Element).SetBigInt(qBig)
+	qAddr, err := q.GetAddress(vm)
+	if err != nil {
+		return err
+	}
+
+	qMv := mem.MemoryValueFromFieldElement(qFelt)
+	return vm.Memory.WriteToAddress(&qAddr, &qMv)
 }
 
 // VerifyECDSASignature hint writes an ECDSA signature to a given address
@@ -50,6 +50,104 @@ func TestVerifyZeroHint(t *testing.T) {
 				},
 				check: varValueEquals("q", feltInt64(1)),
 			},
+			{
+				operanders: []*hintOperander{
+					// values are the 3 results of split(3 * SEC_P)
+					{Name: "val.d0", Kind: apRelative, Value: feltString("77371252455336254296290445")},
+					{Name: "val.d1", Kind: apRelative, Value: feltString("77371252455336267181195263")},
+					{Name: "val.d2", Kind: apRelative, Value: feltString("58028439341502200385896447")},
+					{Name: "q", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newVerifyZeroHint(ctx.operanders["val.d0"], ctx.operanders["q"])
+				},
+				check: varValueEquals("q", feltInt64(3)),
+			},
+			{
+				operanders: []*hintOperander{
+					// values are the 3 results of split(SEC_P + 5)
+					{Name: "val.d0", Kind: apRelative, Value: feltString("77371252455336262886226996")},
+					{Name: "val.d1", Kind: apRelative, Value: feltString("77371252455336267181195263")},
+					{Name: "val.d2", Kind: apRelative, Value: feltString("19342813113834066795298815")},
+					{Name: "q", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newVerifyZeroHint(ctx.operanders["val.d0"], ctx.operanders["q"])
+				},
+				errCheck: errorTextContains("verify_zero: Invalid input (77371252455336262886226996, 77371252455336267181195263, 19342813113834066795298815)"),
+			},
+		},
+		"VerifyZeroMod": {
+			{
+				operanders: []*hintOperander{
+					// values are the 3 results of split(2 * SECP256R1_P)
+					{Name: "val.d0", Kind: apRelative, Value: feltString("77371252455336267181195262")},
+					{Name: "val.d1", Kind: apRelative, Value: feltString("2047")},
+					{Name: "val.d2", Kind: apRelative, Value: feltString("38685626218660934337953792")},
+					{Name: "q", Kind: uninitialized},
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("SECP_P", bigIntString("115792089210356248762697446949407573530086143415290314195533631308867097853951", 10))
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newVerifyZeroModHint(ctx.operanders["val.d0"], ctx.operanders["q"])
+				},
+				check: varValueEquals("q", feltInt64(2)),
+			},
+			{
+				operanders: []*hintOperander{
+					// 5 * 1000003, with a custom modulus
+					{Name: "val.d0", Kind: apRelative, Value: feltString("5000015")},
+					{Name: "val.d1", Kind: apRelative, Value: feltString("0")},
+					{Name: "val.d2", Kind: apRelative, Value: feltString("0")},
+					{Name: "q", Kind: uninitialized},
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("SECP_P", bigIntString("1000003", 10))
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newVerifyZeroModHint(ctx.operanders["val.d0"], ctx.operanders["q"])
+				},
+				check: varValueEquals("q", feltInt64(5)),
+			},
+			{
+				operanders: []*hintOperander{
+					// 5 * 1000003 + 1 isn't a multiple of the modulus
+					{Name: "val.d0", Kind: apRelative, Value: feltString("5000016")},
+					{Name: "val.d1", Kind: apRelative, Value: feltString("0")},
+					{Name: "val.d2", Kind: apRelative, Value: feltString("0")},
+					{Name: "q", Kind: uninitialized},
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariable("SECP_P", bigIntString("1000003", 10))
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newVerifyZeroModHint(ctx.operanders["val.d0"], ctx.operanders["q"])
+				},
+				errCheck: errorTextContains("verify_zero: Invalid input (5000016, 0, 0)"),
+			},
+			{
+				operanders: []*hintOperander{
+					// the modulus has to be in scope
+					{Name: "val.d0", Kind: apRelative, Value: feltString("5000015")},
+					{Name: "val.d1", Kind: apRelative, Value: feltString("0")},
+					{Name: "val.d2", Kind: apRelative, Value: feltString("0")},
+					{Name: "q", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newVerifyZeroModHint(ctx.operanders["val.d0"], ctx.operanders["q"])
+				},
+				errCheck: errorTextContains("variable SECP_P not found in current scope"),
+			},
 		},
 		"VerifyECDSASignature": {
 			{
@@ -181,6 +279,46 @@ func TestVerifyZeroHint(t *testing.T) {
 					"value": bigIntString("140", 10),
 				}),
 			},
+			{
+				// exact division with the secp256k1 order, res = 5 / 3 mod N
+				operanders: []*hintOperander{},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariables(map[string]any{
+						"res": bigIntString("38597363079105398474523661669562635950945854759691634794201721047172720498114", 10),
+						"a":   bigIntString("5", 10),
+						"b":   bigIntString("3", 10),
+						"N":   bigIntString("115792089237316195423570985008687907852837564279074904382605163141518161494337", 10),
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newDivModSafeDivHint()
+				},
+				check: varListInScopeEquals(map[string]any{
+					"value": bigIntString("1", 10),
+				}),
+			},
+			{
+				// res * b - a = 16 isn't a multiple of the secp256k1 order
+				operanders: []*hintOperander{},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					err := ctx.ScopeManager.AssignVariables(map[string]any{
+						"res": bigIntString("7", 10),
+						"a":   bigIntString("5", 10),
+						"b":   bigIntString("3", 10),
+						"N":   bigIntString("115792089237316195423570985008687907852837564279074904382605163141518161494337", 10),
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newDivModSafeDivHint()
+				},
+				errCheck: errorTextContains("16 is not divisible by 115792089237316195423570985008687907852837564279074904382605163141518161494337."),
+			},
 		},
 		"DivModNPackedDivmodV1": {
 			{
@@ -247,6 +385,27 @@ func TestVerifyZeroHint(t *testing.T) {
 					"value": bigIntString("62733347149736974538461843763852691885676254208529184638286052021917647089374", 10),
 				}),
 			},
+			{
+				operanders: []*hintOperander{
+					{Name: "a.d0", Kind: apRelative, Value: feltString("5")},
+					{Name: "a.d1", Kind: apRelative, Value: &utils.FeltZero},
+					{Name: "a.d2", Kind: apRelative, Value: &utils.FeltZero},
+					{Name: "b.d0", Kind: apRelative, Value: feltString("3")},
+					{Name: "b.d1", Kind: apRelative, Value: &utils.FeltZero},
+					{Name: "b.d2", Kind: apRelative, Value: &utils.FeltZero},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newDivModNPackedDivmodV1Hint(ctx.operanders["a.d0"], ctx.operanders["b.d0"])
+				},
+				// the scope is ready for DivModNSafeDiv hint
+				check: allVarValueInScopeEquals(map[string]any{
+					"value": bigIntString("38597363079105398474523661669562635950945854759691634794201721047172720498114", 10),
+					"res":   bigIntString("38597363079105398474523661669562635950945854759691634794201721047172720498114", 10),
+					"a":     big.NewInt(5),
+					"b":     big.NewInt(3),
+					"N":     bigIntString("115792089237316195423570985008687907852837564279074904382605163141518161494337", 10),
+				}),
+			},
 		},
 	})
 }
@@ -2,11 +2,14 @@ package zero
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/hinter"
 	runnerutil "github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/utils"
 	"github.com/NethermindEth/cairo-vm-go/pkg/parsers/starknet"
+	zero "github.com/NethermindEth/cairo-vm-go/pkg/parsers/zero"
+	"github.com/NethermindEth/cairo-vm-go/pkg/utils"
 	VM "github.com/NethermindEth/cairo-vm-go/pkg/vm"
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm/builtins"
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
@@ -269,3 +272,352 @@ func runHinterTests(t *testing.T, tests map[string][]hintTestCase) {
 		}
 	}
 }
+
+func TestGetZeroHints(t *testing.T) {
+	program := &zero.ZeroProgram{
+		Hints: map[string][]zero.Hint{
+			"5":  {{Code: allocSegmentCode}},
+			"12": {{Code: vmEnterScopeCode}, {Code: vmExitScopeCode}},
+		},
+	}
+
+	hints, err := GetZeroHints(program, nil)
+	require.NoError(t, err)
+
+	require.Len(t, hints, 2)
+	require.Len(t, hints[5], 1)
+	require.Equal(t, "AllocSegment", hints[5][0].String())
+	require.Len(t, hints[12], 2)
+	require.Equal(t, "VMEnterScope", hints[12][0].String())
+	require.Equal(t, "VMExitScope", hints[12][1].String())
+
+	program.Hints["pc"] = []zero.Hint{{Code: allocSegmentCode}}
+	_, err = GetZeroHints(program, nil)
+	require.Error(t, err)
+}
+
+func TestGetAssertEqualHint(t *testing.T) {
+	program := &zero.ZeroProgram{
+		Identifiers: map[string]*zero.Identifier{
+			"__main__.A": {IdentifierType: "const", Value: big.NewInt(5)},
+			"__main__.B": {IdentifierType: "const", Value: big.NewInt(5)},
+			"__main__.C": {IdentifierType: "const", Value: big.NewInt(6)},
+		},
+	}
+	getHint := func(code string) (hinter.Hinter, error) {
+		return GetHintFromCode(program, zero.Hint{Code: code, AccessibleScopes: []string{"__main__"}}, 0, nil)
+	}
+
+	hint, err := getHint("assert ids.A == ids.B")
+	require.NoError(t, err)
+	require.Equal(t, "AssertEqual", hint.String())
+	require.Equal(t, []string{"A", "B"}, hint.(hinter.OperandDeclarer).DeclaredOperands())
+	require.NoError(t, hint.Execute(VM.DefaultVirtualMachine(), nil))
+
+	hint, err = getHint("assert ids.A == ids.C")
+	require.NoError(t, err)
+	require.EqualError(t, hint.Execute(VM.DefaultVirtualMachine(), nil), "`assert ids.A == ids.C` failed: 5 != 6")
+
+	_, err = getHint("assert ids.A == ids.D")
+	require.ErrorContains(t, err, "missing reference D")
+
+	_, err = getHint("assert ids.A == 5")
+	require.ErrorContains(t, err, "not identified hint")
+}
+
+func TestCustomHints(t *testing.T) {
+	const customCode = "ids.a = 42"
+
+	program := &zero.ZeroProgram{
+		Identifiers: map[string]*zero.Identifier{
+			"__main__.a": {
+				IdentifierType: "reference",
+				References:     []zero.Reference{{Pc: 0, Value: "[cast(ap, felt*)]"}},
+			},
+		},
+	}
+	getHint := func(code string, customHints CustomHints) (hinter.Hinter, error) {
+		return GetHintFromCode(program, zero.Hint{
+			Code: code,
+			FlowTrackingData: zero.FlowTrackingData{
+				ReferenceIds: map[string]uint64{"__main__.a": 0},
+			},
+		}, 0, customHints)
+	}
+
+	// A custom hint supplements the built-in ones
+	customHints := CustomHints{
+		customCode: func(resolver ReferenceResolver) (hinter.Hinter, error) {
+			a, err := resolver.GetResOperander("a")
+			if err != nil {
+				return nil, err
+			}
+			return &GenericZeroHinter{
+				Name: "Custom",
+				Op: func(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+					addr, err := a.GetAddress(vm)
+					if err != nil {
+						return err
+					}
+					mv := memory.MemoryValueFromUint(uint64(42))
+					return vm.Memory.WriteToAddress(&addr, &mv)
+				},
+			}, nil
+		},
+	}
+
+	hint, err := getHint(customCode, customHints)
+	require.NoError(t, err)
+	require.Equal(t, "Custom", hint.String())
+
+	vm := VM.DefaultVirtualMachine()
+	require.NoError(t, hint.Execute(vm, nil))
+	value, err := vm.Memory.ReadAsElement(VM.ExecutionSegment, vm.Context.Ap)
+	require.NoError(t, err)
+	require.Equal(t, *feltUint64(42), value)
+
+	// the custom hints only apply to the programs they are given for
+	_, err = getHint(customCode, nil)
+	require.ErrorContains(t, err, "not identified hint")
+
+	// A custom hint takes precedence over the built-in one with the same code
+	hint, err = getHint(allocSegmentCode, customHints)
+	require.NoError(t, err)
+	require.Equal(t, "AllocSegment", hint.String())
+
+	customHints[allocSegmentCode] = func(ReferenceResolver) (hinter.Hinter, error) {
+		return &GenericZeroHinter{Name: "CustomAllocSegment"}, nil
+	}
+	hint, err = getHint(allocSegmentCode, customHints)
+	require.NoError(t, err)
+	require.Equal(t, "CustomAllocSegment", hint.String())
+}
+
+func TestCairoKeccakFinalizeFromCode(t *testing.T) {
+	// The hint code emitted by the cairo_keccak library resolves to the built-in hint
+	program := &zero.ZeroProgram{
+		Identifiers: map[string]*zero.Identifier{
+			"__main__.keccak_ptr_end": {
+				IdentifierType: "reference",
+				References:     []zero.Reference{{Pc: 0, Value: "[cast(ap, felt*)]"}},
+			},
+		},
+	}
+	code := "# Add dummy pairs of input and output.\n" +
+		"_keccak_state_size_felts = int(ids.KECCAK_STATE_SIZE_FELTS)\n" +
+		"_block_size = int(ids.BLOCK_SIZE)\n" +
+		"assert 0 <= _keccak_state_size_felts < 100\n" +
+		"assert 0 <= _block_size < 10\n" +
+		"inp = [0] * _keccak_state_size_felts\n" +
+		"padding = (inp + keccak_func(inp)) * _block_size\n" +
+		"segments.write_arg(ids.keccak_ptr_end, padding)"
+
+	hint, err := GetHintFromCode(program, zero.Hint{
+		Code: code,
+		FlowTrackingData: zero.FlowTrackingData{
+			ReferenceIds: map[string]uint64{"__main__.keccak_ptr_end": 0},
+		},
+	}, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, "CairoKeccakFinalize", hint.String())
+}
+
+func TestRecoverYFromCode(t *testing.T) {
+	program := &zero.ZeroProgram{
+		Identifiers: map[string]*zero.Identifier{
+			"starkware.cairo.common.ec.recover_y.x": {
+				IdentifierType: "reference",
+				References:     []zero.Reference{{Pc: 0, Value: "[cast(fp + (-3), felt*)]"}},
+			},
+			"starkware.cairo.common.ec.recover_y.p": {
+				IdentifierType: "reference",
+				References:     []zero.Reference{{Pc: 0, Value: "[cast(fp, starkware.cairo.common.ec_point.EcPoint*)]"}},
+			},
+		},
+	}
+	hint, err := GetHintFromCode(program, zero.Hint{
+		Code: "from starkware.crypto.signature.signature import ALPHA, BETA, FIELD_PRIME\n" +
+			"from starkware.python.math_utils import recover_y\n" +
+			"ids.p.x = ids.x\n" +
+			"# This raises an exception if `x` is not on the curve.\n" +
+			"ids.p.y = recover_y(ids.x, ALPHA, BETA, FIELD_PRIME)",
+		FlowTrackingData: zero.FlowTrackingData{
+			ReferenceIds: map[string]uint64{
+				"starkware.cairo.common.ec.recover_y.x": 0,
+				"starkware.cairo.common.ec.recover_y.p": 1,
+			},
+		},
+	}, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, "RecoverY", hint.String())
+	require.Equal(t, []string{"x", "p"}, hint.(hinter.OperandDeclarer).DeclaredOperands())
+
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Fp = 3
+	x := memory.MemoryValueFromFieldElement(feltUint64(1))
+	require.NoError(t, vm.Memory.Write(VM.ExecutionSegment, 0, &x))
+	require.NoError(t, hint.Execute(vm, nil))
+
+	px, err := vm.Memory.ReadAsElement(VM.ExecutionSegment, 3)
+	require.NoError(t, err)
+	require.Equal(t, *feltUint64(1), px)
+
+	// the written y is on the curve and is the smallest of the two roots
+	py, err := vm.Memory.ReadAsElement(VM.ExecutionSegment, 4)
+	require.NoError(t, err)
+	var ySquared, expected fp.Element
+	ySquared.Square(&py)
+	expected.Add(feltUint64(2), &utils.Beta)
+	require.Equal(t, expected, ySquared)
+	negY := new(fp.Element).Neg(&py)
+	require.True(t, utils.FeltLt(&py, negY))
+}
+
+func TestLoadProgramFromCode(t *testing.T) {
+	program := &zero.ZeroProgram{
+		Identifiers: map[string]*zero.Identifier{
+			"__main__.program_header": {
+				IdentifierType: "reference",
+				References:     []zero.Reference{{Pc: 0, Value: "[cast(fp, felt*)]"}},
+			},
+		},
+	}
+	hint, err := GetHintFromCode(program, zero.Hint{
+		Code: loadProgramCode,
+		FlowTrackingData: zero.FlowTrackingData{
+			ReferenceIds: map[string]uint64{"__main__.program_header": 0},
+		},
+	}, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, "LoadProgram", hint.String())
+
+	// a header without builtins followed by a single bytecode cell
+	vm := VM.DefaultVirtualMachine()
+	programDataPtr, err := vm.Memory.AllocateSegment([]*fp.Element{
+		feltUint64(4), feltUint64(0), feltUint64(0), feltUint64(0), feltUint64(42),
+	})
+	require.NoError(t, err)
+	ctx := &hinter.HintRunnerContext{}
+	hinter.InitializeScopeManager(ctx, map[string]any{"program_data_ptr": programDataPtr})
+	require.NoError(t, hint.Execute(vm, ctx))
+
+	bytecode, err := vm.Memory.ReadAsElement(VM.ExecutionSegment, 4)
+	require.NoError(t, err)
+	require.Equal(t, *feltUint64(42), bytecode)
+	programAddress, err := ctx.ScopeManager.GetVariableValue("program_address")
+	require.NoError(t, err)
+	require.Equal(t, memory.MemoryAddress{SegmentIndex: VM.ExecutionSegment, Offset: 4}, programAddress)
+}
+
+func TestReferenceResolverConstants(t *testing.T) {
+	resolver := NewReferenceResolver()
+	resolver.SetConstants(map[string]*zero.Identifier{
+		"__main__.SHIFT":                {IdentifierType: "const", Value: big.NewInt(2)},
+		"__main__.assert_250_bit.SHIFT": {IdentifierType: "const", Value: big.NewInt(3)},
+		"__main__.assert_250_bit.f":     {IdentifierType: "function"},
+	}, []string{"__main__", "__main__.assert_250_bit"})
+
+	// The innermost scope wins.
+	shift, err := resolver.GetReference("SHIFT")
+	require.NoError(t, err)
+	require.Equal(t, hinter.Immediate(*new(fp.Element).SetUint64(3)), shift)
+
+	_, err = resolver.GetReference("f")
+	require.Error(t, err)
+
+	// References take precedence over constants.
+	require.NoError(t, resolver.AddReference("__main__.SHIFT", hinter.Deref{Deref: hinter.ApCellRef(0)}))
+	shift, err = resolver.GetReference("SHIFT")
+	require.NoError(t, err)
+	require.Equal(t, hinter.Deref{Deref: hinter.ApCellRef(0)}, shift)
+}
+
+func TestReferenceResolverDeclaredOperands(t *testing.T) {
+	resolver := NewReferenceResolver()
+	require.NoError(t, resolver.AddReference("__main__.a", hinter.Deref{Deref: hinter.ApCellRef(0)}))
+
+	declared := &GenericZeroHinter{Name: "Declared", Operands: []string{"a"}}
+	require.NoError(t, resolver.CheckDeclaredOperands(declared))
+
+	missing := &GenericZeroHinter{Name: "Missing", Operands: []string{"a", "b"}}
+	require.ErrorContains(t, resolver.CheckDeclaredOperands(missing), "missing reference b")
+
+	// hints that don't declare their operands aren't checked
+	undeclared := &GenericZeroHinter{Name: "Undeclared"}
+	require.NoError(t, resolver.CheckDeclaredOperands(undeclared))
+}
+
+func TestAssert250bitsOptionalConstants(t *testing.T) {
+	newResolver := func() hintReferenceResolver {
+		resolver := NewReferenceResolver()
+		for i, name := range []string{"low", "high", "value"} {
+			require.NoError(t, resolver.AddReference("__main__."+name, hinter.Deref{Deref: hinter.ApCellRef(i)}))
+		}
+		return resolver
+	}
+
+	// UPPER_BOUND and SHIFT fall back to their standard values when they aren't declared
+	resolver := newResolver()
+	_, err := createAssert250bitsHinter(resolver)
+	require.NoError(t, err)
+
+	// but a declared constant that can't be used as an operand is an error
+	resolver = newResolver()
+	require.NoError(t, resolver.AddReference("__main__.SHIFT", hinter.ApCellRef(0)))
+	_, err = createAssert250bitsHinter(resolver)
+	require.ErrorContains(t, err, "expected SHIFT to be ResOperander")
+}
+
+func TestDeclaredOperandsFromCode(t *testing.T) {
+	const lazyCode = "ids.b = ids.a"
+
+	program := &zero.ZeroProgram{
+		Identifiers: map[string]*zero.Identifier{
+			"__main__.a": {
+				IdentifierType: "reference",
+				References:     []zero.Reference{{Pc: 0, Value: "[cast(ap, felt*)]"}},
+			},
+		},
+	}
+	// A hint that only resolves its references when it runs still fails when
+	// the program is loaded, since it declares the operands it uses
+	customHints := CustomHints{
+		lazyCode: func(ReferenceResolver) (hinter.Hinter, error) {
+			return &GenericZeroHinter{
+				Name:     "Lazy",
+				Operands: []string{"a", "b"},
+				Op: func(*VM.VirtualMachine, *hinter.HintRunnerContext) error {
+					return fmt.Errorf("unreachable")
+				},
+			}, nil
+		},
+	}
+	_, err := GetHintFromCode(program, zero.Hint{
+		Code: lazyCode,
+		FlowTrackingData: zero.FlowTrackingData{
+			ReferenceIds: map[string]uint64{"__main__.a": 0},
+		},
+	}, 0, customHints)
+	require.EqualError(t, err, "hint Lazy: missing reference b")
+}
+
+func TestUsortBodyOptionalMaxSize(t *testing.T) {
+	newResolver := func() hintReferenceResolver {
+		resolver := NewReferenceResolver()
+		for i, name := range []string{"input", "input_len", "output", "output_len", "multiplicities"} {
+			require.NoError(t, resolver.AddReference("__main__."+name, hinter.Deref{Deref: hinter.ApCellRef(i)}))
+		}
+		return resolver
+	}
+
+	// max_size is optional, the hint then relies on the scope
+	resolver := newResolver()
+	_, err := createUsortBodyHinter(resolver)
+	require.NoError(t, err)
+
+	// but a declared max_size that can't be used as an operand is an error
+	resolver = newResolver()
+	require.NoError(t, resolver.AddReference("__main__.max_size", hinter.ApCellRef(0)))
+	_, err = createUsortBodyHinter(resolver)
+	require.ErrorContains(t, err, "expected max_size to be ResOperander")
+}
@@ -287,7 +287,7 @@ func createUint256SignedNNHinter(resolver hintReferenceResolver) (hinter.Hinter,
 //
 // `newUint256UnsignedDivRemHint` takes 4 operanders as arguments
 //   - `a` is the `uint256` variable that will be divided
-//   - `div` is the `uint256` variable that will divide `a`
+//   - `div` is the `uint256` variable that will divide `a`, which can't be zero
 //   - `quotient` is the quotient of the Euclidean division of `a` by `div`
 //   - `remainder` is the remainder of the Euclidean division of `a` by `div`
 func newUint256UnsignedDivRemHint(a, div, quotient, remainder hinter.ResOperander) hinter.Hinter {
@@ -324,6 +324,9 @@ func newUint256UnsignedDivRemHint(a, div, quotient, remainder hinter.ResOperande
 
 			aBig := new(big.Int).Add(new(big.Int).Lsh(&aHighBig, 128), &aLowBig)
 			divBig := new(big.Int).Add(new(big.Int).Lsh(&divHighBig, 128), &divLowBig)
+			if divBig.Sign() == 0 {
+				return fmt.Errorf("cannot divide %v by zero", aBig)
+			}
 			quotBig := new(big.Int).Div(aBig, divBig)
 			remBig := new(big.Int).Mod(aBig, divBig)
 
@@ -79,6 +79,24 @@ func TestZeroHintUint256(t *testing.T) {
 					"carry_high": feltUint64(0),
 				}),
 			},
+			// (2**256 - 1) + (2**256 - 1) carries out of both parts
+			{
+				operanders: []*hintOperander{
+					{Name: "a.low", Kind: fpRelative, Value: feltString("340282366920938463463374607431768211455")},
+					{Name: "a.high", Kind: fpRelative, Value: feltString("340282366920938463463374607431768211455")},
+					{Name: "b.low", Kind: apRelative, Value: feltString("340282366920938463463374607431768211455")},
+					{Name: "b.high", Kind: apRelative, Value: feltString("340282366920938463463374607431768211455")},
+					{Name: "carry_low", Kind: uninitialized},
+					{Name: "carry_high", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newUint256AddHint(ctx.operanders["a.low"], ctx.operanders["b.low"], ctx.operanders["carry_low"], ctx.operanders["carry_high"])
+				},
+				check: allVarValueEquals(map[string]*fp.Element{
+					"carry_low":  feltUint64(1),
+					"carry_high": feltUint64(1),
+				}),
+			},
 		},
 		"Split64": {
 			// `high` is zero
@@ -126,6 +144,66 @@ func TestZeroHintUint256(t *testing.T) {
 					"high": feltString("196159429230833779654668657131193454380566933979560673279"), // felt(-5) >> 64
 				}),
 			},
+			// `a` is the largest 64-bit value
+			{
+				operanders: []*hintOperander{
+					{Name: "a", Kind: fpRelative, Value: feltString("18446744073709551615")},
+					{Name: "low", Kind: uninitialized},
+					{Name: "high", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSplit64Hint(ctx.operanders["a"], ctx.operanders["low"], ctx.operanders["high"])
+				},
+				check: allVarValueEquals(map[string]*fp.Element{
+					"low":  feltString("18446744073709551615"),
+					"high": feltUint64(0),
+				}),
+			},
+			// `a` is 2**64
+			{
+				operanders: []*hintOperander{
+					{Name: "a", Kind: fpRelative, Value: feltString("18446744073709551616")},
+					{Name: "low", Kind: uninitialized},
+					{Name: "high", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSplit64Hint(ctx.operanders["a"], ctx.operanders["low"], ctx.operanders["high"])
+				},
+				check: allVarValueEquals(map[string]*fp.Element{
+					"low":  feltUint64(0),
+					"high": feltUint64(1),
+				}),
+			},
+			// `a` is 2**128 - 1, filling the high 64-bit word
+			{
+				operanders: []*hintOperander{
+					{Name: "a", Kind: fpRelative, Value: feltString("340282366920938463463374607431768211455")},
+					{Name: "low", Kind: uninitialized},
+					{Name: "high", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSplit64Hint(ctx.operanders["a"], ctx.operanders["low"], ctx.operanders["high"])
+				},
+				check: allVarValueEquals(map[string]*fp.Element{
+					"low":  feltString("18446744073709551615"),
+					"high": feltString("18446744073709551615"),
+				}),
+			},
+			// `a` is 2**191 + 1, split_64 being used on values up to 2**192
+			{
+				operanders: []*hintOperander{
+					{Name: "a", Kind: fpRelative, Value: feltString("3138550867693340381917894711603833208051177722232017256449")},
+					{Name: "low", Kind: uninitialized},
+					{Name: "high", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newSplit64Hint(ctx.operanders["a"], ctx.operanders["low"], ctx.operanders["high"])
+				},
+				check: allVarValueEquals(map[string]*fp.Element{
+					"low":  feltUint64(1),
+					"high": feltString("170141183460469231731687303715884105728"),
+				}),
+			},
 		},
 		"Uint256Sqrt": {
 			{
@@ -188,6 +266,38 @@ func TestZeroHintUint256(t *testing.T) {
 					"root.high": feltUint64(0),
 				}),
 			},
+			// (2**128 - 1)**2 is a perfect square
+			{
+				operanders: []*hintOperander{
+					{Name: "n.low", Kind: fpRelative, Value: feltUint64(1)},
+					{Name: "n.high", Kind: fpRelative, Value: feltString("340282366920938463463374607431768211454")},
+					{Name: "root.low", Kind: uninitialized},
+					{Name: "root.high", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newUint256SqrtHint(ctx.operanders["n.low"], ctx.operanders["root.low"])
+				},
+				check: allVarValueEquals(map[string]*fp.Element{
+					"root.low":  feltString("340282366920938463463374607431768211455"),
+					"root.high": feltUint64(0),
+				}),
+			},
+			// 2**256 - 1 is not a perfect square, its root is rounded down
+			{
+				operanders: []*hintOperander{
+					{Name: "n.low", Kind: fpRelative, Value: feltString("340282366920938463463374607431768211455")},
+					{Name: "n.high", Kind: fpRelative, Value: feltString("340282366920938463463374607431768211455")},
+					{Name: "root.low", Kind: uninitialized},
+					{Name: "root.high", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newUint256SqrtHint(ctx.operanders["n.low"], ctx.operanders["root.low"])
+				},
+				check: allVarValueEquals(map[string]*fp.Element{
+					"root.low":  feltString("340282366920938463463374607431768211455"),
+					"root.high": feltUint64(0),
+				}),
+			},
 		},
 		"Uint256SignedNN": {
 			{
@@ -275,6 +385,45 @@ func TestZeroHintUint256(t *testing.T) {
 					"remainder.high": feltUint64(0),
 				}),
 			},
+			// the dividend is smaller than the divisor
+			{
+				operanders: []*hintOperander{
+					{Name: "a.low", Kind: fpRelative, Value: feltUint64(7)},
+					{Name: "a.high", Kind: fpRelative, Value: feltUint64(1)},
+					{Name: "div.low", Kind: fpRelative, Value: feltUint64(0)},
+					{Name: "div.high", Kind: fpRelative, Value: feltUint64(2)},
+					{Name: "quotient.low", Kind: uninitialized},
+					{Name: "quotient.high", Kind: uninitialized},
+					{Name: "remainder.low", Kind: uninitialized},
+					{Name: "remainder.high", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newUint256UnsignedDivRemHint(ctx.operanders["a.low"], ctx.operanders["div.low"], ctx.operanders["quotient.low"], ctx.operanders["remainder.low"])
+				},
+				check: allVarValueEquals(map[string]*fp.Element{
+					"quotient.low":   feltUint64(0),
+					"quotient.high":  feltUint64(0),
+					"remainder.low":  feltUint64(7),
+					"remainder.high": feltUint64(1),
+				}),
+			},
+			// the divisor is zero
+			{
+				operanders: []*hintOperander{
+					{Name: "a.low", Kind: fpRelative, Value: feltUint64(7)},
+					{Name: "a.high", Kind: fpRelative, Value: feltUint64(1)},
+					{Name: "div.low", Kind: fpRelative, Value: feltUint64(0)},
+					{Name: "div.high", Kind: fpRelative, Value: feltUint64(0)},
+					{Name: "quotient.low", Kind: uninitialized},
+					{Name: "quotient.high", Kind: uninitialized},
+					{Name: "remainder.low", Kind: uninitialized},
+					{Name: "remainder.high", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newUint256UnsignedDivRemHint(ctx.operanders["a.low"], ctx.operanders["div.low"], ctx.operanders["quotient.low"], ctx.operanders["remainder.low"])
+				},
+				errCheck: errorTextContains("cannot divide 340282366920938463463374607431768211463 by zero"),
+			},
 		},
 		"Uint256MulDivMod": {
 			{
@@ -358,6 +507,34 @@ func TestZeroHintUint256(t *testing.T) {
 					"remainder.high":     &utils.FeltZero,
 				}),
 			},
+			// (2**256 - 1)**2 divided by 5 * 2**128 + 3 has a 384-bit quotient and a remainder
+			{
+				operanders: []*hintOperander{
+					{Name: "a.low", Kind: apRelative, Value: feltString("340282366920938463463374607431768211455")},
+					{Name: "a.high", Kind: apRelative, Value: feltString("340282366920938463463374607431768211455")},
+					{Name: "b.low", Kind: apRelative, Value: feltString("340282366920938463463374607431768211455")},
+					{Name: "b.high", Kind: apRelative, Value: feltString("340282366920938463463374607431768211455")},
+					{Name: "div.low", Kind: apRelative, Value: feltUint64(3)},
+					{Name: "div.high", Kind: apRelative, Value: feltUint64(5)},
+					{Name: "quotient_low.low", Kind: uninitialized},
+					{Name: "quotient_low.high", Kind: uninitialized},
+					{Name: "quotient_high.low", Kind: uninitialized},
+					{Name: "quotient_high.high", Kind: uninitialized},
+					{Name: "remainder.low", Kind: uninitialized},
+					{Name: "remainder.high", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newUint256MulDivModHint(ctx.operanders["a.low"], ctx.operanders["b.low"], ctx.operanders["div.low"], ctx.operanders["quotient_low.low"], ctx.operanders["quotient_high.low"], ctx.operanders["remainder.low"])
+				},
+				check: allVarValueEquals(map[string]*fp.Element{
+					"quotient_low.low":   feltString("51722919771982646446432940329628768141"),
+					"quotient_low.high":  feltString("27222589353675077077069968594541456916"),
+					"quotient_high.low":  feltString("68056473384187692692674921486353642291"),
+					"quotient_high.high": &utils.FeltZero,
+					"remainder.low":      feltString("185113607604990524124075786442881907034"),
+					"remainder.high":     feltUint64(2),
+				}),
+			},
 		},
 	})
 }
@@ -40,13 +40,15 @@ func createUsortEnterScopeHinter() (hinter.Hinter, error) {
 
 // UsortBody hint sorts the input array of field elements. The sorting results in generation of output array without duplicates and multiplicites array, where each element represents the number of times the corresponding element in the output array appears in the input array. The output and multiplicities arrays are written to the new, separate segments in memory.
 //
-// `newSplit64Hint` takes 5 operanders as arguments
+// `newUsortBodyHint` takes 6 operanders as arguments
 //   - `input` is the pointer to the base of input array of field elements
 //   - `inputLen` is the length of the input array
 //   - `output` is the pointer to the base of the output array of field elements
 //   - `outputLen` is the length of the output array
 //   - `multiplicities` is the pointer to the base of the multiplicities array of field elements
-func newUsortBodyHint(input, inputLen, output, outputLen, multiplicities hinter.ResOperander) hinter.Hinter {
+//   - `maxSize` is the maximum input length, only used when `__usort_max_size` is not
+//     in scope. It can be nil
+func newUsortBodyHint(input, inputLen, output, outputLen, multiplicities, maxSize hinter.ResOperander) hinter.Hinter {
 	return &GenericZeroHinter{
 		Name: "UsortBody",
 		Op: func(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
@@ -82,12 +84,24 @@ func newUsortBodyHint(input, inputLen, output, outputLen, multiplicities hinter.
 				return err
 			}
 
+			var usortMaxSize uint64
 			usortMaxSizeInterface, err := ctx.ScopeManager.GetVariableValue("__usort_max_size")
-			if err != nil {
-				return err
+			if err == nil {
+				var ok bool
+				usortMaxSize, ok = usortMaxSizeInterface.(uint64)
+				if !ok {
+					return fmt.Errorf("casting __usort_max_size into a uint64 failed")
+				}
+			} else {
+				if maxSize == nil {
+					return err
+				}
+				usortMaxSize, err = hinter.ResolveAsUint64(vm, maxSize)
+				if err != nil {
+					return err
+				}
 			}
 
-			usortMaxSize := usortMaxSizeInterface.(uint64)
 			if inputLenValue > usortMaxSize {
 				return fmt.Errorf("usort() can only be used with input_len<=%d.\n Got: input_len=%d", usortMaxSize, inputLenValue)
 			}
@@ -156,9 +170,16 @@ func newUsortBodyHint(input, inputLen, output, outputLen, multiplicities hinter.
 				}
 			}
 
-			multiplicitiesArray := make([]*fp.Element, len(outputArray))
+			// The multiplicities follow the order of the sorted distinct values. They are
+			// also kept in scope so that later hints can read them
+			multiplicitiesArray := make([]uint64, len(outputArray))
 			for i, v := range outputArray {
-				multiplicitiesArray[i] = new(fp.Element).SetUint64(uint64(len(positionsDict[v])))
+				multiplicitiesArray[i] = uint64(len(positionsDict[v]))
+			}
+
+			err = ctx.ScopeManager.AssignVariable("multiplicities", multiplicitiesArray)
+			if err != nil {
+				return err
 			}
 
 			multiplicitesSegmentBaseAddr := vm.Memory.AllocateEmptySegment()
@@ -173,24 +194,27 @@ func newUsortBodyHint(input, inputLen, output, outputLen, multiplicities hinter.
 				return err
 			}
 
-			for _, v := range multiplicitiesArray {
-				multiplicitiesElementMV := memory.MemoryValueFromFieldElement(v)
-				err = vm.Memory.WriteToAddress(&multiplicitesSegmentBaseAddr, &multiplicitiesElementMV)
-				if err != nil {
-					return err
-				}
-
-				multiplicitesSegmentBaseAddr, err = multiplicitesSegmentBaseAddr.AddOffset(1)
-				if err != nil {
-					return err
-				}
-			}
-
-			return nil
+			return writeMultiplicities(vm, multiplicitesSegmentBaseAddr, multiplicitiesArray)
 		},
 	}
 }
 
+func writeMultiplicities(vm *VM.VirtualMachine, addr memory.MemoryAddress, multiplicities []uint64) error {
+	for _, v := range multiplicities {
+		multiplicitiesElementMV := memory.MemoryValueFromUint(v)
+		err := vm.Memory.WriteToAddress(&addr, &multiplicitiesElementMV)
+		if err != nil {
+			return err
+		}
+
+		addr, err = addr.AddOffset(1)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func createUsortBodyHinter(resolver hintReferenceResolver) (hinter.Hinter, error) {
 	input, err := resolver.GetResOperander("input")
 	if err != nil {
@@ -217,7 +241,13 @@ func createUsortBodyHinter(resolver hintReferenceResolver) (hinter.Hinter, error
 		return nil, err
 	}
 
-	return newUsortBodyHint(input, input_len, output, output_len, multiplicities), nil
+	// Some programs pass the max size as an operand instead of through the scope
+	maxSize, err := resolver.getOptionalResOperander("max_size")
+	if err != nil {
+		return nil, err
+	}
+
+	return newUsortBodyHint(input, input_len, output, output_len, multiplicities, maxSize), nil
 }
 
 // UsortVerify hint prepares for verifying the presence of duplicates of
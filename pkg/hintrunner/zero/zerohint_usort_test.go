@@ -6,6 +6,7 @@ import (
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/hinter"
 	"github.com/NethermindEth/cairo-vm-go/pkg/utils"
+	VM "github.com/NethermindEth/cairo-vm-go/pkg/vm"
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 	"github.com/stretchr/testify/require"
 )
@@ -21,6 +22,60 @@ func TestZeroHintUsort(t *testing.T) {
 			},
 		},
 		"UsortBody": {
+			{
+				// max size read from the operand when it is not in scope
+				operanders: []*hintOperander{
+					{Name: "input", Kind: apRelative, Value: addr(7)},
+					{Name: "input_length", Kind: apRelative, Value: feltUint64(11)},
+					{Name: "output", Kind: uninitialized},
+					{Name: "output_length", Kind: uninitialized},
+					{Name: "multiplicities", Kind: uninitialized},
+					{Name: "max_size", Kind: immediate, Value: feltUint64(10)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicities"], ctx.operanders["max_size"])
+				},
+				errCheck: errorTextContains(fmt.Sprintf("usort() can only be used with input_len<=%d.\n Got: input_len=%d", 10, 11)),
+			},
+			{
+				// the scope variable takes precedence over the operand
+				operanders: []*hintOperander{
+					{Name: "input", Kind: apRelative, Value: addr(7)},
+					{Name: "input_length", Kind: apRelative, Value: feltUint64(11)},
+					{Name: "output", Kind: uninitialized},
+					{Name: "output_length", Kind: uninitialized},
+					{Name: "multiplicities", Kind: uninitialized},
+					{Name: "max_size", Kind: immediate, Value: feltUint64(100)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicities"], ctx.operanders["max_size"])
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					ctx.ScopeManager.EnterScope(map[string]any{
+						"__usort_max_size": uint64(10),
+					})
+				},
+				errCheck: errorTextContains(fmt.Sprintf("usort() can only be used with input_len<=%d.\n Got: input_len=%d", 10, 11)),
+			},
+			{
+				// the scope variable must be a uint64
+				operanders: []*hintOperander{
+					{Name: "input", Kind: apRelative, Value: addr(7)},
+					{Name: "input_length", Kind: apRelative, Value: feltUint64(11)},
+					{Name: "output", Kind: uninitialized},
+					{Name: "output_length", Kind: uninitialized},
+					{Name: "multiplicities", Kind: uninitialized},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					return newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicities"], nil)
+				},
+				ctxInit: func(ctx *hinter.HintRunnerContext) {
+					ctx.ScopeManager.EnterScope(map[string]any{
+						"__usort_max_size": feltUint64(10),
+					})
+				},
+				errCheck: errorTextContains("casting __usort_max_size into a uint64 failed"),
+			},
 			{
 				// input length greater then allowed size
 				operanders: []*hintOperander{
@@ -31,7 +86,7 @@ func TestZeroHintUsort(t *testing.T) {
 					{Name: "multiplicities", Kind: uninitialized},
 				},
 				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
-					return newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicites"])
+					return newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicites"], nil)
 				},
 				ctxInit: func(ctx *hinter.HintRunnerContext) {
 					ctx.ScopeManager.EnterScope(map[string]any{
@@ -53,7 +108,7 @@ func TestZeroHintUsort(t *testing.T) {
 					{Name: "multiplicities", Kind: uninitialized},
 				},
 				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
-					return newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicities"])
+					return newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicities"], nil)
 				},
 				ctxInit: func(ctx *hinter.HintRunnerContext) {
 					ctx.ScopeManager.EnterScope(map[string]any{
@@ -89,7 +144,7 @@ func TestZeroHintUsort(t *testing.T) {
 					{Name: "multiplicities", Kind: uninitialized},
 				},
 				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
-					return newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicities"])
+					return newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicities"], nil)
 				},
 				ctxInit: func(ctx *hinter.HintRunnerContext) {
 					ctx.ScopeManager.EnterScope(map[string]any{
@@ -130,7 +185,7 @@ func TestZeroHintUsort(t *testing.T) {
 					{Name: "multiplicities", Kind: uninitialized},
 				},
 				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
-					return newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicities"])
+					return newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicities"], nil)
 				},
 				ctxInit: func(ctx *hinter.HintRunnerContext) {
 					ctx.ScopeManager.EnterScope(map[string]any{
@@ -155,6 +210,8 @@ func TestZeroHintUsort(t *testing.T) {
 						feltUint64(1),
 						feltUint64(3),
 					})(t, ctx)
+					// same order as the sorted distinct values
+					varValueInScopeEquals("multiplicities", []uint64{1, 1, 2, 2, 1, 3})(t, ctx)
 				},
 			},
 			{
@@ -167,7 +224,7 @@ func TestZeroHintUsort(t *testing.T) {
 					{Name: "multiplicities", Kind: uninitialized},
 				},
 				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
-					return newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicities"])
+					return newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicities"], nil)
 				},
 				ctxInit: func(ctx *hinter.HintRunnerContext) {
 					ctx.ScopeManager.EnterScope(map[string]any{
@@ -178,6 +235,7 @@ func TestZeroHintUsort(t *testing.T) {
 					varValueEquals("output_length", feltUint64(0))(t, ctx)
 					consecutiveVarAddrResolvedValueEquals("output", []*fp.Element{})(t, ctx)
 					consecutiveVarAddrResolvedValueEquals("multiplicities", []*fp.Element{})(t, ctx)
+					varValueInScopeEquals("multiplicities", []uint64{})(t, ctx)
 				},
 			},
 		},
@@ -285,6 +343,53 @@ func TestZeroHintUsort(t *testing.T) {
 				},
 			},
 		},
+		"UsortSequence": {
+			{
+				// usort([3, 1, 3]) runs every hint of the library in order: the positions
+				// of 3 are popped from the scope variables set by the previous hints
+				operanders: []*hintOperander{
+					{Name: "input", Kind: apRelative, Value: addr(11)},
+					{Name: "input_length", Kind: apRelative, Value: feltUint64(3)},
+					{Name: "output", Kind: uninitialized},
+					{Name: "output_length", Kind: uninitialized},
+					{Name: "multiplicities", Kind: uninitialized},
+					{Name: "next_item_index_0", Kind: uninitialized},
+					{Name: "next_item_index_1", Kind: uninitialized},
+					{Name: "input.0", Kind: apRelative, Value: feltUint64(3)},
+					{Name: "input.1", Kind: apRelative, Value: feltUint64(1)},
+					{Name: "input.2", Kind: apRelative, Value: feltUint64(3)},
+					{Name: "value", Kind: immediate, Value: feltUint64(3)},
+				},
+				makeHinter: func(ctx *hintTestContext) hinter.Hinter {
+					sequence := []hinter.Hinter{
+						newUsortEnterScopeHint(),
+						newUsortBodyHint(ctx.operanders["input"], ctx.operanders["input_length"], ctx.operanders["output"], ctx.operanders["output_length"], ctx.operanders["multiplicities"], nil),
+						newUsortVerifyHint(ctx.operanders["value"]),
+						newUsortVerifyMultiplicityBodyHint(ctx.operanders["next_item_index_0"]),
+						newUsortVerifyMultiplicityBodyHint(ctx.operanders["next_item_index_1"]),
+						newUsortVerifyMultiplicityAssertHint(),
+					}
+					return &GenericZeroHinter{
+						Name: "UsortSequence",
+						Op: func(vm *VM.VirtualMachine, runnerCtx *hinter.HintRunnerContext) error {
+							for _, h := range sequence {
+								if err := h.Execute(vm, runnerCtx); err != nil {
+									return fmt.Errorf("%s: %w", h, err)
+								}
+							}
+							return nil
+						},
+					}
+				},
+				check: func(t *testing.T, ctx *hintTestContext) {
+					varValueEquals("output_length", feltUint64(2))(t, ctx)
+					varValueEquals("next_item_index_0", feltUint64(0))(t, ctx)
+					varValueEquals("next_item_index_1", feltUint64(1))(t, ctx)
+					varValueInScopeEquals("positions", []uint64{})(t, ctx)
+					varValueInScopeEquals("last_pos", uint64(3))(t, ctx)
+				},
+			},
+		},
 		"UsortVerifyMultiplicityAssert": {
 			{
 				ctxInit: func(ctx *hinter.HintRunnerContext) {
@@ -115,6 +115,20 @@ func allVarValueEquals(expectedValues map[string]*fp.Element) func(t *testing.T,
 	}
 }
 
+// builtinSegmentValuesEqual checks the first cells of the segment of the given builtin
+func builtinSegmentValuesEqual(builtinName string, expectedValues []*fp.Element) func(t *testing.T, ctx *hintTestContext) {
+	return func(t *testing.T, ctx *hintTestContext) {
+		segment, ok := ctx.vm.Memory.FindSegmentWithBuiltin(builtinName)
+		require.True(t, ok, "no %s segment", builtinName)
+		for offset, expected := range expectedValues {
+			value := segment.Peek(uint64(offset))
+			actualFelt, err := value.FieldElement()
+			require.NoError(t, err)
+			require.Equal(t, expected, actualFelt, "%s cell %d value mismatch", builtinName, offset)
+		}
+	}
+}
+
 func consecutiveVarAddrResolvedValueEquals(varName string, expectedValues []*fp.Element) func(t *testing.T, ctx *hintTestContext) {
 	return func(t *testing.T, ctx *hintTestContext) {
 		o := ctx.operanders[varName]
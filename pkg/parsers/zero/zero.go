@@ -2,6 +2,7 @@ package zero
 
 import (
 	"encoding/json"
+	"math/big"
 	"os"
 
 	starknetParser "github.com/NethermindEth/cairo-vm-go/pkg/parsers/starknet"
@@ -89,10 +90,11 @@ type Identifier struct {
 	Size           int            `json:"size"`
 	Members        map[string]any `json:"members"`
 	References     []Reference    `json:"references"`
+	// Value is only set for `const` identifiers.
+	Value *big.Int `json:"value"`
 
 	// These fields are listed as any-typed fields before we need them.
 	Decorators any `json:"decorators"`
-	Value      any `json:"value"`
 }
 
 func (z ZeroProgram) MarshalToFile(filepath string) error {
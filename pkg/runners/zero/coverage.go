@@ -0,0 +1,80 @@
+package zero
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/parsers/zero"
+	"golang.org/x/exp/maps"
+)
+
+// Coverage returns the sorted program pcs executed during the run
+func (runner *ZeroRunner) Coverage() ([]uint64, error) {
+	if !runner.collectCoverage {
+		return nil, errors.New("the coverage is only collected with the CollectCoverage option")
+	}
+	pcs := maps.Keys(runner.coverage)
+	slices.Sort(pcs)
+	return pcs, nil
+}
+
+// SourceCoverage maps the executed pcs to the source lines of the program, using its
+// debug info. For each file, every line holding an instruction is associated to
+// whether one of its instructions was executed
+func SourceCoverage(debugInfo *zero.DebugInfo, executedPcs []uint64) (map[string]map[uint64]bool, error) {
+	if len(debugInfo.InstructionLocations) == 0 {
+		return nil, errors.New("the program has no debug info")
+	}
+
+	executed := make(map[uint64]bool, len(executedPcs))
+	for _, pc := range executedPcs {
+		executed[pc] = true
+	}
+
+	coverage := make(map[string]map[uint64]bool)
+	for pcKey, location := range debugInfo.InstructionLocations {
+		pc, err := strconv.ParseUint(pcKey, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("instruction location pc %s: %w", pcKey, err)
+		}
+
+		filename := location.Inst.InputFile["filename"]
+		lines, ok := coverage[filename]
+		if !ok {
+			lines = make(map[uint64]bool)
+			coverage[filename] = lines
+		}
+		for line := location.Inst.StartLine; line <= location.Inst.EndLine; line++ {
+			lines[line] = lines[line] || executed[pc]
+		}
+	}
+	return coverage, nil
+}
+
+// EncodeLcov encodes the source coverage in the lcov tracefile format
+func EncodeLcov(coverage map[string]map[uint64]bool) []byte {
+	var buf bytes.Buffer
+
+	filenames := maps.Keys(coverage)
+	slices.Sort(filenames)
+	for _, filename := range filenames {
+		fmt.Fprintf(&buf, "SF:%s\n", filename)
+
+		lines := maps.Keys(coverage[filename])
+		slices.Sort(lines)
+		hitLines := 0
+		for _, line := range lines {
+			hits := 0
+			if coverage[filename][line] {
+				hits = 1
+				hitLines++
+			}
+			fmt.Fprintf(&buf, "DA:%d,%d\n", line, hits)
+		}
+		fmt.Fprintf(&buf, "LH:%d\nLF:%d\nend_of_record\n", hitLines, len(lines))
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,60 @@
+package zero
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/hinter"
+	"github.com/NethermindEth/cairo-vm-go/pkg/parsers/zero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverage(t *testing.T) {
+	// The jump is always taken, so the instruction at pc 4 is never executed
+	program := createProgram(`
+        [ap] = 5, ap++;
+        jmp rel 4 if [ap - 1] != 0;
+        [ap] = 1, ap++;
+        ret;
+    `)
+
+	hints := make(map[uint64][]hinter.Hinter)
+	runner, err := NewRunner(program, hints, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "plain"})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+	_, err = runner.Coverage()
+	require.ErrorContains(t, err, "only collected with the CollectCoverage option")
+
+	runner, err = NewRunner(program, hints, RunnerConfig{CollectCoverage: true, MaxSteps: math.MaxUint64, Layout: "plain"})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+	pcs, err := runner.Coverage()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0, 2, 6}, pcs)
+
+	instructionAt := func(line uint64) zero.InstructionLocation {
+		return zero.InstructionLocation{Inst: zero.Location{
+			InputFile: map[string]string{"filename": "main.cairo"},
+			StartLine: line,
+			EndLine:   line,
+		}}
+	}
+	debugInfo := zero.DebugInfo{InstructionLocations: map[string]zero.InstructionLocation{
+		"0": instructionAt(2),
+		"2": instructionAt(3),
+		"4": instructionAt(4),
+		"6": instructionAt(5),
+	}}
+	coverage, err := SourceCoverage(&debugInfo, pcs)
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[uint64]bool{
+		"main.cairo": {2: true, 3: true, 4: false, 5: true},
+	}, coverage)
+	require.Equal(t,
+		"SF:main.cairo\nDA:2,1\nDA:3,1\nDA:4,0\nDA:5,1\nLH:3\nLF:4\nend_of_record\n",
+		string(EncodeLcov(coverage)),
+	)
+
+	_, err = SourceCoverage(&zero.DebugInfo{}, pcs)
+	require.ErrorContains(t, err, "the program has no debug info")
+}
@@ -0,0 +1,63 @@
+package zero
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+// ParseProgramInputJSON reads a JSON object mapping the program argument names to their
+// values. A value is either a felt, given as a JSON number or a numeric string in
+// decimal or hexadecimal, or an array of values, which can itself hold arrays.
+// Felts are returned as `fp.Element` and arrays as `[]any`. The result is the raw
+// argument map, it isn't converted to a ProgramInput
+func ParseProgramInputJSON(r io.Reader) (map[string]any, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var rawInput map[string]any
+	if err := decoder.Decode(&rawInput); err != nil {
+		return nil, fmt.Errorf("cannot decode program input: %w", err)
+	}
+
+	input := make(map[string]any, len(rawInput))
+	for name, rawValue := range rawInput {
+		value, err := parseInputValue(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %w", name, err)
+		}
+		input[name] = value
+	}
+	return input, nil
+}
+
+func parseInputValue(rawValue any) (any, error) {
+	switch rawValue := rawValue.(type) {
+	case json.Number:
+		return parseInputFelt(rawValue.String())
+	case string:
+		return parseInputFelt(rawValue)
+	case []any:
+		values := make([]any, len(rawValue))
+		for i := range rawValue {
+			value, err := parseInputValue(rawValue[i])
+			if err != nil {
+				return nil, fmt.Errorf("index %d: %w", i, err)
+			}
+			values[i] = value
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("%v is neither a felt nor an array of felts", rawValue)
+	}
+}
+
+func parseInputFelt(s string) (fp.Element, error) {
+	felt, err := new(fp.Element).SetString(s)
+	if err != nil {
+		return fp.Element{}, fmt.Errorf("%q is not a numeric value", s)
+	}
+	return *felt, nil
+}
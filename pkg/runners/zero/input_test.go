@@ -0,0 +1,47 @@
+package zero
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProgramInputJSONFlat(t *testing.T) {
+	input, err := ParseProgramInputJSON(strings.NewReader(`{"a": 1, "b": "0x10", "c": "-1"}`))
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]any{
+		"a": *new(fp.Element).SetUint64(1),
+		"b": *new(fp.Element).SetUint64(16),
+		"c": *new(fp.Element).Neg(new(fp.Element).SetUint64(1)),
+	}, input)
+}
+
+func TestParseProgramInputJSONArrays(t *testing.T) {
+	input, err := ParseProgramInputJSON(strings.NewReader(`{"values": [1, "2", [3, []]]}`))
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]any{
+		"values": []any{
+			*new(fp.Element).SetUint64(1),
+			*new(fp.Element).SetUint64(2),
+			[]any{*new(fp.Element).SetUint64(3), []any{}},
+		},
+	}, input)
+}
+
+func TestParseProgramInputJSONMalformed(t *testing.T) {
+	_, err := ParseProgramInputJSON(strings.NewReader(`{"a": [1, "two"]}`))
+	require.ErrorContains(t, err, `argument a: index 1: "two" is not a numeric value`)
+
+	_, err = ParseProgramInputJSON(strings.NewReader(`{"a": 1.5}`))
+	require.ErrorContains(t, err, `argument a: "1.5" is not a numeric value`)
+
+	_, err = ParseProgramInputJSON(strings.NewReader(`{"a": {"b": 1}}`))
+	require.ErrorContains(t, err, "argument a: map[b:1] is neither a felt nor an array of felts")
+
+	_, err = ParseProgramInputJSON(strings.NewReader(`[1, 2]`))
+	require.ErrorContains(t, err, "cannot decode program input")
+}
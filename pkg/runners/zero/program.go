@@ -2,6 +2,7 @@ package zero
 
 import (
 	"fmt"
+	"strings"
 
 	sn "github.com/NethermindEth/cairo-vm-go/pkg/parsers/starknet"
 	"github.com/NethermindEth/cairo-vm-go/pkg/parsers/zero"
@@ -13,12 +14,22 @@ type Program struct {
 	Bytecode []*f.Element
 	// given a string it returns the pc for that function call
 	Entrypoints map[string]uint64
+	// given a function name it returns the number of its explicit arguments
+	ArgsSizes map[string]uint64
 	// it stores the start and end label pcs
 	Labels map[string]uint64
 	// builtins
 	Builtins []sn.Builtin
 }
 
+// IsProofMode reports whether the program was compiled with `--proof_mode`, which
+// wraps main between the `__start__` and `__end__` labels
+func (program *Program) IsProofMode() bool {
+	_, hasStart := program.Labels["__start__"]
+	_, hasEnd := program.Labels["__end__"]
+	return hasStart && hasEnd
+}
+
 func LoadCairoZeroProgram(cairoZeroJson *zero.ZeroProgram) (*Program, error) {
 	// bytecode
 	bytecode := make([]*f.Element, len(cairoZeroJson.Data))
@@ -43,9 +54,15 @@ func LoadCairoZeroProgram(cairoZeroJson *zero.ZeroProgram) (*Program, error) {
 		return nil, err
 	}
 
+	argsSizes, err := extractArgsSizes(cairoZeroJson)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Program{
 		Bytecode:    bytecode,
 		Entrypoints: entrypoints,
+		ArgsSizes:   argsSizes,
 		Labels:      labels,
 		Builtins:    cairoZeroJson.Builtins,
 	}, nil
@@ -89,6 +106,30 @@ func extractLabels(json *zero.ZeroProgram) (map[string]uint64, error) {
 	return labels, nil
 }
 
+// extractArgsSizes reads the size of the `Args` struct the compiler generates for
+// every function
+func extractArgsSizes(json *zero.ZeroProgram) (map[string]uint64, error) {
+	argsSizes := make(map[string]uint64)
+	err := scanIdentifiers(
+		json,
+		func(key string, ident *zero.Identifier) error {
+			function, isArgs := strings.CutSuffix(key, ".Args")
+			if !isArgs || ident.IdentifierType != "struct" || !strings.HasPrefix(function, json.MainScope+".") {
+				return nil
+			}
+			if ident.Size < 0 {
+				return fmt.Errorf("%s has a negative size %d", key, ident.Size)
+			}
+			argsSizes[function[len(json.MainScope)+1:]] = uint64(ident.Size)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("extracting arguments sizes: %w", err)
+	}
+	return argsSizes, nil
+}
+
 func scanIdentifiers(json *zero.ZeroProgram, f func(key string, ident *zero.Identifier) error) error {
 	for key, ident := range json.Identifiers {
 		if err := f(key, ident); err != nil {
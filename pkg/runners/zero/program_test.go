@@ -28,6 +28,23 @@ func TestLoadCairoZeroProgram(t *testing.T) {
                     "decorators": [],
                     "pc": 4,
                     "type": "function"
+                },
+                "__main__.main.Args": {
+                    "full_name": "__main__.main.Args",
+                    "members": {},
+                    "size": 0,
+                    "type": "struct"
+                },
+                "__main__.fib.Args": {
+                    "full_name": "__main__.fib.Args",
+                    "members": {
+                        "n": {
+                            "cairo_type": "felt",
+                            "offset": 0
+                        }
+                    },
+                    "size": 1,
+                    "type": "struct"
                 }
             }
         }
@@ -60,6 +77,10 @@ func TestLoadCairoZeroProgram(t *testing.T) {
 			"main": 0,
 			"fib":  4,
 		},
+		ArgsSizes: map[string]uint64{
+			"main": 0,
+			"fib":  1,
+		},
 		Labels: map[string]uint64{},
 	},
 		program,
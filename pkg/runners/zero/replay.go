@@ -0,0 +1,41 @@
+package zero
+
+import (
+	"fmt"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm"
+)
+
+// ReplayTrace runs the program and checks that every step goes through the
+// same registers as the given relocated trace, such as one produced by
+// `ExecutionTrace`. It errors at the first step that diverges, which exposes
+// hints that don't behave deterministically between runs
+func (runner *ZeroRunner) ReplayTrace(trace []vm.Trace) error {
+	// the replay needs the trace of its own run to compare against
+	runner.collectTrace = true
+	if err := runner.Run(); err != nil {
+		return fmt.Errorf("replaying trace: %w", err)
+	}
+
+	replayed, err := runner.vm.ExecutionTrace()
+	if err != nil {
+		return err
+	}
+
+	for step := 0; step < len(trace) && step < len(replayed); step++ {
+		if trace[step] != replayed[step] {
+			return fmt.Errorf(
+				"trace diverges at step %d: expected pc %d, ap %d, fp %d, got pc %d, ap %d, fp %d",
+				step, trace[step].Pc, trace[step].Ap, trace[step].Fp,
+				replayed[step].Pc, replayed[step].Ap, replayed[step].Fp,
+			)
+		}
+	}
+	if len(trace) != len(replayed) {
+		return fmt.Errorf(
+			"trace diverges at step %d: expected %d steps, got %d",
+			min(len(trace), len(replayed)), len(trace), len(replayed),
+		)
+	}
+	return nil
+}
@@ -1,8 +1,11 @@
 package zero
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner"
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/hinter"
@@ -21,32 +24,172 @@ type ZeroRunner struct {
 	vm         *vm.VirtualMachine
 	hintrunner hintrunner.HintRunner
 	// config
-	proofmode bool
-	maxsteps  uint64
+	proofmode       bool
+	collectTrace    bool
+	collectCoverage bool
+	maxsteps        uint64
+	// zero means no limit
+	maxSegmentSize uint64
+	builtinWorkers int
+	trackAccesses  bool
+	// the function run instead of main, with its arguments
+	entryPoint string
+	arguments  []fp.Element
+	// the context of a RunWithContext run, checked every contextCheckInterval steps
+	ctx                  context.Context
+	contextCheckInterval uint64
 	// auxiliar
 	runFinished bool
 	layout      builtins.Layout
+
+	// number of cells at the start of the execution segment that belong to the
+	// public memory, i.e. the initial stack of a proof mode run
+	executionPublicMemorySize uint64
+	// program pcs executed during the run, only filled when collecting the coverage
+	coverage map[uint64]bool
+}
+
+// RunnerConfig gathers the options used to create a Runner
+type RunnerConfig struct {
+	// ProofMode runs the program from its `__start__` label and pads the execution
+	// so that the trace and memory can be used to build a proof
+	ProofMode bool
+	// CollectTrace records the execution trace outside of proof mode. Recording the
+	// trace allocates one entry per step, so it is better left off when only the
+	// final state or the output are needed. It is always on in proof mode
+	CollectTrace bool
+	// CollectCoverage records the set of executed program pcs, which can be
+	// mapped to source lines with the program debug info
+	CollectCoverage bool
+	// MaxSteps limits the number of steps the runner executes
+	MaxSteps uint64
+	// Layout is the name of the layout defining the available builtins
+	Layout string
+	// MaxSegmentSize limits the size each memory segment can grow to during the
+	// execution, catching runaway memory usage. Zero means there is no limit
+	MaxSegmentSize uint64
+	// BuiltinWorkers is the number of builtin segments FinalizeBuiltins deduces
+	// in parallel. Zero or one deduces them sequentially
+	BuiltinWorkers int
+	// ProgramInput holds the values the hints can access from the start of the run
+	ProgramInput ProgramInput
+	// TrackAccesses records the memory cells accessed during the run, which
+	// AccessedAddresses gives back. It slows down every memory access
+	TrackAccesses bool
+	// EntryPoint is the name of the function Run executes instead of main, with
+	// ProgramInput.Arguments as its arguments. It can't be used in proof mode
+	EntryPoint string
+	// ContextCheckInterval is the number of steps between two checks of the context
+	// given to RunWithContext. Zero means DefaultContextCheckInterval
+	ContextCheckInterval uint64
+}
+
+// DefaultContextCheckInterval is the number of steps between two checks of the
+// RunWithContext context when RunnerConfig.ContextCheckInterval isn't set
+const DefaultContextCheckInterval = 1024
+
+// ProgramInput gathers the values given to the hints through their root scope
+type ProgramInput struct {
+	// InitialDict seeds the `initial_dict` scope variable, which the first
+	// `dict_new` hint turns into a dictionary
+	InitialDict map[fp.Element]fp.Element
+	// InitialDefaultDict seeds the `__initial_default_dict` scope variable, which gives
+	// its initial content to the first dictionary created by `default_dict_new`
+	InitialDefaultDict map[fp.Element]fp.Element
+	// Arguments are the explicit arguments given to the RunnerConfig.EntryPoint function
+	Arguments []fp.Element
+}
+
+// globals gives the root scope variables of the program input
+func (input *ProgramInput) globals() map[string]any {
+	globals := make(map[string]any)
+	if input.InitialDict != nil {
+		globals["initial_dict"] = dictMemoryValues(input.InitialDict)
+	}
+	if input.InitialDefaultDict != nil {
+		globals["__initial_default_dict"] = dictMemoryValues(input.InitialDefaultDict)
+	}
+	return globals
+}
+
+// dictMemoryValues converts the values of a dictionary to the memory values the
+// dictionary manager holds
+func dictMemoryValues(dict map[fp.Element]fp.Element) map[fp.Element]mem.MemoryValue {
+	values := make(map[fp.Element]mem.MemoryValue, len(dict))
+	for key, value := range dict {
+		values[key] = mem.MemoryValueFromFieldElement(&value)
+	}
+	return values
 }
 
 // Creates a new Runner of a Cairo Zero program
-func NewRunner(program *Program, hints map[uint64][]hinter.Hinter, proofmode bool, maxsteps uint64, layoutName string) (ZeroRunner, error) {
-	hintrunner := hintrunner.NewHintRunner(hints)
-	layout, err := builtins.GetLayout(layoutName)
+func NewRunner(program *Program, hints map[uint64][]hinter.Hinter, config RunnerConfig) (ZeroRunner, error) {
+	hintrunner := hintrunner.NewHintRunnerWithGlobals(hints, config.ProgramInput.globals())
+	layout, err := builtins.GetLayout(config.Layout)
 	if err != nil {
 		return ZeroRunner{}, err
 	}
+	// check if all builtins from the program are in the layout
+	if err := layout.CheckBuiltins(program.Builtins); err != nil {
+		return ZeroRunner{}, err
+	}
+	if config.EntryPoint != "" {
+		if err := checkEntryPoint(program, &config); err != nil {
+			return ZeroRunner{}, err
+		}
+	}
+	if config.ProofMode {
+		if _, _, err := proofModeLabels(program); err != nil {
+			return ZeroRunner{}, err
+		}
+	}
+	contextCheckInterval := config.ContextCheckInterval
+	if contextCheckInterval == 0 {
+		contextCheckInterval = DefaultContextCheckInterval
+	}
 	return ZeroRunner{
-		program:    program,
-		hintrunner: hintrunner,
-		proofmode:  proofmode,
-		maxsteps:   maxsteps,
-		layout:     layout,
+		program:              program,
+		hintrunner:           hintrunner,
+		proofmode:            config.ProofMode,
+		collectTrace:         config.CollectTrace,
+		collectCoverage:      config.CollectCoverage,
+		maxsteps:             config.MaxSteps,
+		maxSegmentSize:       config.MaxSegmentSize,
+		builtinWorkers:       config.BuiltinWorkers,
+		trackAccesses:        config.TrackAccesses,
+		entryPoint:           config.EntryPoint,
+		arguments:            config.ProgramInput.Arguments,
+		contextCheckInterval: contextCheckInterval,
+		layout:               layout,
 	}, nil
 }
 
+// checkEntryPoint validates that the program has the requested entry point and that
+// it takes as many arguments as given
+func checkEntryPoint(program *Program, config *RunnerConfig) error {
+	if config.ProofMode {
+		return errors.New("an entry point cannot be run in proof mode")
+	}
+	if _, ok := program.Entrypoints[config.EntryPoint]; !ok {
+		return fmt.Errorf("entry point %s not found in the program", config.EntryPoint)
+	}
+	argsSize := program.ArgsSizes[config.EntryPoint]
+	if uint64(len(config.ProgramInput.Arguments)) != argsSize {
+		return fmt.Errorf(
+			"entry point %s expects %d arguments, got %d",
+			config.EntryPoint, argsSize, len(config.ProgramInput.Arguments),
+		)
+	}
+	return nil
+}
+
 // RunEntryPoint is like Run, but it executes the program starting from the given PC offset.
 // This PC offset is expected to be a start from some function inside the loaded program.
 func (runner *ZeroRunner) RunEntryPoint(pc uint64) error {
+	return runner.runEntryPoint(pc, nil)
+}
+
+func (runner *ZeroRunner) runEntryPoint(pc uint64, arguments []*fp.Element) error {
 	if runner.runFinished {
 		return errors.New("cannot re-run using the same runner")
 	}
@@ -60,7 +203,7 @@ func (runner *ZeroRunner) RunEntryPoint(pc uint64) error {
 
 	returnFp := memory.AllocateEmptySegment()
 	mvReturnFp := mem.MemoryValueFromMemoryAddress(&returnFp)
-	end, err := runner.initializeEntrypoint(pc, nil, &mvReturnFp, memory)
+	end, err := runner.initializeEntrypoint(pc, arguments, &mvReturnFp, memory)
 	if err != nil {
 		return err
 	}
@@ -77,6 +220,14 @@ func (runner *ZeroRunner) Run() error {
 		return errors.New("cannot re-run using the same runner")
 	}
 
+	if runner.entryPoint != "" {
+		arguments := make([]*fp.Element, len(runner.arguments))
+		for i := range runner.arguments {
+			arguments[i] = &runner.arguments[i]
+		}
+		return runner.runEntryPoint(runner.program.Entrypoints[runner.entryPoint], arguments)
+	}
+
 	end, err := runner.InitializeMainEntrypoint()
 	if err != nil {
 		return fmt.Errorf("initializing main entry point: %w", err)
@@ -98,8 +249,34 @@ func (runner *ZeroRunner) Run() error {
 	return nil
 }
 
+// RunWithContext is like Run, but it stops with the context error once `ctx` is
+// done. The context is checked every RunnerConfig.ContextCheckInterval steps, and
+// the state of a cancelled run is kept for inspection
+func (runner *ZeroRunner) RunWithContext(ctx context.Context) error {
+	runner.ctx = ctx
+	defer func() { runner.ctx = nil }()
+	return runner.Run()
+}
+
+// checkContext returns the error of the RunWithContext context once it is done
+func (runner *ZeroRunner) checkContext() error {
+	if runner.ctx == nil || runner.steps()%runner.contextCheckInterval != 0 {
+		return nil
+	}
+	select {
+	case <-runner.ctx.Done():
+		return runner.ctx.Err()
+	default:
+		return nil
+	}
+}
+
 func (runner *ZeroRunner) initializeSegments() (*mem.Memory, error) {
 	memory := mem.InitializeEmptyMemory()
+	memory.MaxSegmentSize = runner.maxSegmentSize
+	if runner.trackAccesses {
+		memory.TrackAccesses()
+	}
 	_, err := memory.AllocateSegment(runner.program.Bytecode) // ProgramSegment
 	if err != nil {
 		return nil, err
@@ -117,16 +294,12 @@ func (runner *ZeroRunner) InitializeMainEntrypoint() (mem.MemoryAddress, error)
 		return mem.UnknownAddress, err
 	}
 
-	if runner.proofmode {
-		initialPCOffset, ok := runner.program.Labels["__start__"]
-		if !ok {
-			return mem.UnknownAddress,
-				errors.New("start label not found. Try compiling with `--proof_mode`")
-		}
-		endPcOffset, ok := runner.program.Labels["__end__"]
-		if !ok {
-			return mem.UnknownAddress,
-				errors.New("end label not found. Try compiling with `--proof_mode`")
+	// programs compiled with `--proof_mode` expect the proof mode initial stack
+	// even when they aren't run in proof mode
+	if runner.proofmode || runner.program.IsProofMode() {
+		initialPCOffset, endPcOffset, err := proofModeLabels(runner.program)
+		if err != nil {
+			return mem.UnknownAddress, err
 		}
 
 		stack, err := runner.initializeBuiltins(memory)
@@ -138,6 +311,7 @@ func (runner *ZeroRunner) InitializeMainEntrypoint() (mem.MemoryAddress, error)
 			vm.ProgramSegment,
 			len(runner.program.Bytecode)+2,
 		), mem.EmptyMemoryValueAsFelt()}, stack...)
+		runner.executionPublicMemorySize = uint64(len(stack))
 
 		if err := runner.initializeVm(&mem.MemoryAddress{
 			SegmentIndex: vm.ProgramSegment,
@@ -161,6 +335,20 @@ func (runner *ZeroRunner) InitializeMainEntrypoint() (mem.MemoryAddress, error)
 	return runner.initializeEntrypoint(mainPCOffset, nil, &mvReturnFp, memory)
 }
 
+// proofModeLabels returns the offsets of the `__start__` and `__end__` labels
+// a program must declare to be run in proof mode
+func proofModeLabels(program *Program) (uint64, uint64, error) {
+	start, ok := program.Labels["__start__"]
+	if !ok {
+		return 0, 0, errors.New("start label not found. Try compiling with `--proof_mode`")
+	}
+	end, ok := program.Labels["__end__"]
+	if !ok {
+		return 0, 0, errors.New("end label not found. Try compiling with `--proof_mode`")
+	}
+	return start, end, nil
+}
+
 func (runner *ZeroRunner) initializeEntrypoint(
 	initialPCOffset uint64, arguments []*f.Element, returnFp *mem.MemoryValue, memory *mem.Memory,
 ) (mem.MemoryAddress, error) {
@@ -181,20 +369,6 @@ func (runner *ZeroRunner) initializeEntrypoint(
 }
 
 func (runner *ZeroRunner) initializeBuiltins(memory *mem.Memory) ([]mem.MemoryValue, error) {
-	builtinsSet := make(map[starknet.Builtin]bool)
-	for _, bRunner := range runner.layout.Builtins {
-		builtinsSet[bRunner.Builtin] = true
-	}
-	// check if all builtins from the program are in the layout
-	for _, programBuiltin := range runner.program.Builtins {
-		if _, found := builtinsSet[programBuiltin]; !found {
-			builtinName, err := programBuiltin.MarshalJSON()
-			if err != nil {
-				return []mem.MemoryValue{}, err
-			}
-			return []mem.MemoryValue{}, fmt.Errorf("builtin %s not found in the layout: %s", builtinName, runner.layout.Name)
-		}
-	}
 	stack := []mem.MemoryValue{}
 	// adding to the stack only the builtins that are both in the program and in the layout
 	for _, bRunner := range runner.layout.Builtins {
@@ -223,7 +397,7 @@ func (runner *ZeroRunner) initializeVm(
 		Pc: *initialPC,
 		Ap: offset + uint64(len(stack)),
 		Fp: offset + uint64(len(stack)),
-	}, memory, vm.VirtualMachineConfig{ProofMode: runner.proofmode})
+	}, memory, vm.VirtualMachineConfig{ProofMode: runner.proofmode, CollectTrace: runner.collectTrace})
 	return err
 }
 
@@ -238,7 +412,10 @@ func (runner *ZeroRunner) RunUntilPc(pc *mem.MemoryAddress) error {
 				runner.maxsteps,
 			)
 		}
-		if err := runner.vm.RunStep(&runner.hintrunner); err != nil {
+		if err := runner.checkContext(); err != nil {
+			return fmt.Errorf("pc %s step %d: %w", runner.pc(), runner.steps(), err)
+		}
+		if err := runner.runStep(); err != nil {
 			return fmt.Errorf("pc %s step %d: %w", runner.pc(), runner.steps(), err)
 		}
 	}
@@ -256,7 +433,10 @@ func (runner *ZeroRunner) RunFor(steps uint64) error {
 				runner.maxsteps,
 			)
 		}
-		if err := runner.vm.RunStep(&runner.hintrunner); err != nil {
+		if err := runner.checkContext(); err != nil {
+			return fmt.Errorf("pc %s step %d: %w", runner.pc(), runner.steps(), err)
+		}
+		if err := runner.runStep(); err != nil {
 			return fmt.Errorf(
 				"pc %s step %d: %w",
 				runner.pc(),
@@ -268,19 +448,37 @@ func (runner *ZeroRunner) RunFor(steps uint64) error {
 	return nil
 }
 
+func (runner *ZeroRunner) runStep() error {
+	if runner.collectCoverage && runner.vm.Context.Pc.SegmentIndex == vm.ProgramSegment {
+		if runner.coverage == nil {
+			runner.coverage = make(map[uint64]bool)
+		}
+		runner.coverage[runner.vm.Context.Pc.Offset] = true
+	}
+	return runner.vm.RunStep(&runner.hintrunner)
+}
+
 // EndRun is responsible for running the additional steps after the program was executed,
 // until the checkUsedCells doesn't return any error.
 // Since this vm always finishes the run of the program at the number of steps that is a power of two in the proof mode,
 // there is no need to run additional steps before the loop.
 func (runner *ZeroRunner) EndRun() {
+	if err := runner.endRun(); err != nil {
+		panic(err)
+	}
+}
+
+// endRun is EndRun returning the error of the additional steps instead of panicking
+func (runner *ZeroRunner) endRun() error {
 	if runner.proofmode {
 		for runner.checkUsedCells() != nil {
 			pow2Steps := utils.NextPowerOfTwo(runner.vm.Step + 1)
 			if err := runner.RunFor(pow2Steps); err != nil {
-				panic(err)
+				return err
 			}
 		}
 	}
+	return nil
 }
 
 // checkUsedCells returns error if not enough steps were made to allocate required number of cells for builtins
@@ -366,6 +564,92 @@ func (runner *ZeroRunner) FinalizeSegments() error {
 	return nil
 }
 
+// FinalizeBuiltins deduces every pending output cell of the builtin segments, instead
+// of waiting for them to be read. Each segment is handled by a single worker, so the
+// resulting memory doesn't depend on the number of workers
+func (runner *ZeroRunner) FinalizeBuiltins() error {
+	if runner.vm == nil {
+		return errors.New("cannot finalize the builtins of an uninitialized runner")
+	}
+
+	type builtinSegment struct {
+		runner  mem.BuiltinRunner
+		segment *mem.Segment
+	}
+	segments := []builtinSegment{}
+	for _, bRunner := range runner.layout.Builtins {
+		segment, ok := runner.vm.Memory.FindSegmentWithBuiltin(bRunner.Runner.String())
+		if ok {
+			segments = append(segments, builtinSegment{bRunner.Runner, segment})
+		}
+	}
+
+	workers := utils.Max(runner.builtinWorkers, 1)
+	errs := make([]error, len(segments))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < utils.Min(workers, len(segments)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = builtins.DeduceSegment(segments[i].runner, segments[i].segment)
+			}
+		}()
+	}
+	for i := range segments {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	// errors are reported in the layout order, whatever worker found them first
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("builtin %s: %w", segments[i].runner, err)
+		}
+	}
+	return nil
+}
+
+// SecureRun validates the builtin segments of the last run before proving it:
+// every instance must have all its inputs, public memory can't have holes and
+// every deduced cell must match the builtin deduction.
+func (runner *ZeroRunner) SecureRun() error {
+	if runner.vm == nil {
+		return errors.New("cannot validate an uninitialized runner")
+	}
+
+	for _, bRunner := range runner.layout.Builtins {
+		builtinSegment, ok := runner.vm.Memory.FindSegmentWithBuiltin(bRunner.Runner.String())
+		if !ok {
+			continue
+		}
+		if err := builtins.CheckSegmentSecurity(bRunner.Runner, builtinSegment); err != nil {
+			return fmt.Errorf("builtin %s: %w", bRunner.Runner, err)
+		}
+	}
+	return nil
+}
+
+// ECDSAPrivateInput gives the ecdsa section of the air private input: the signatures
+// used during the last run, at their relocated addresses.
+func (runner *ZeroRunner) ECDSAPrivateInput() ([]builtins.ECDSAPrivateInput, error) {
+	if runner.vm == nil {
+		return nil, errors.New("cannot get the private input from an uninitialized runner")
+	}
+
+	segmentsOffsets, _ := runner.vm.Memory.RelocationOffsets()
+	for i, segment := range runner.vm.Memory.Segments {
+		ecdsaRunner, ok := segment.BuiltinRunner.(*builtins.ECDSA)
+		if !ok {
+			continue
+		}
+		return ecdsaRunner.AirPrivateInput(segment, segmentsOffsets[i])
+	}
+	return nil, errors.New("the program doesn't use the ecdsa builtin")
+}
+
 func (runner *ZeroRunner) BuildProof() ([]byte, []byte, error) {
 	relocatedTrace, err := runner.vm.ExecutionTrace()
 	if err != nil {
@@ -375,6 +659,125 @@ func (runner *ZeroRunner) BuildProof() ([]byte, []byte, error) {
 	return vm.EncodeTrace(relocatedTrace), vm.EncodeMemory(runner.vm.RelocateMemory()), nil
 }
 
+// PublicMemoryEntry is a relocated memory cell the verifier has access to
+type PublicMemoryEntry struct {
+	Address uint64
+	Value   fp.Element
+	Page    uint64
+}
+
+// ProofArtifacts gathers everything a prover needs from a proof mode run
+type ProofArtifacts struct {
+	Trace  []vm.Trace
+	Memory []*fp.Element
+	// PublicMemory holds the program, the initial stack and the output cells.
+	// Output pages aren't supported, so every entry belongs to page 0
+	PublicMemory []PublicMemoryEntry
+	// BuiltinSegmentSizes gives the final size of each builtin segment, by builtin name
+	BuiltinSegmentSizes map[string]uint64
+}
+
+// Proof pads the last run and finalizes its segments, validates the builtin
+// segments, and then gathers the relocated trace, memory and public memory.
+// It errors if the runner isn't in proof mode.
+func (runner *ZeroRunner) Proof() (ProofArtifacts, error) {
+	if !runner.proofmode {
+		return ProofArtifacts{}, errors.New("proof artifacts can only be built in proof mode")
+	}
+
+	if err := runner.endRun(); err != nil {
+		return ProofArtifacts{}, fmt.Errorf("cannot end run: %w", err)
+	}
+	if err := runner.FinalizeSegments(); err != nil {
+		return ProofArtifacts{}, fmt.Errorf("cannot finalize segments: %w", err)
+	}
+	if err := runner.SecureRun(); err != nil {
+		return ProofArtifacts{}, fmt.Errorf("secure run: %w", err)
+	}
+
+	trace, err := runner.vm.ExecutionTrace()
+	if err != nil {
+		return ProofArtifacts{}, err
+	}
+	memory := runner.vm.RelocateMemory()
+
+	publicMemory, err := runner.publicMemory(memory)
+	if err != nil {
+		return ProofArtifacts{}, err
+	}
+
+	builtinSegmentSizes := make(map[string]uint64)
+	for _, segment := range runner.vm.Memory.Segments {
+		if _, ok := segment.BuiltinRunner.(*mem.NoBuiltin); ok {
+			continue
+		}
+		builtinSegmentSizes[segment.BuiltinRunner.String()] = segment.Len()
+	}
+
+	return ProofArtifacts{
+		Trace:               trace,
+		Memory:              memory,
+		PublicMemory:        publicMemory,
+		BuiltinSegmentSizes: builtinSegmentSizes,
+	}, nil
+}
+
+// publicMemory lists the relocated public memory cells: the whole program segment,
+// the initial stack at the start of the execution segment, and the output segment
+func (runner *ZeroRunner) publicMemory(relocatedMemory []*fp.Element) ([]PublicMemoryEntry, error) {
+	segmentsOffsets, _ := runner.vm.Memory.RelocationOffsets()
+
+	publicSegments := map[uint64]uint64{
+		vm.ProgramSegment:   runner.vm.Memory.Segments[vm.ProgramSegment].Len(),
+		vm.ExecutionSegment: runner.executionPublicMemorySize,
+	}
+	for i, segment := range runner.vm.Memory.Segments {
+		if _, ok := segment.BuiltinRunner.(*builtins.Output); ok {
+			publicSegments[uint64(i)] = segment.Len()
+		}
+	}
+
+	publicMemory := []PublicMemoryEntry{}
+	for segmentIndex := uint64(0); segmentIndex < uint64(len(runner.vm.Memory.Segments)); segmentIndex++ {
+		size, ok := publicSegments[segmentIndex]
+		if !ok {
+			continue
+		}
+		for offset := uint64(0); offset < size; offset++ {
+			address := segmentsOffsets[segmentIndex] + offset
+			value := relocatedMemory[address]
+			if value == nil {
+				return nil, fmt.Errorf("public memory cell %d:%d is unknown", segmentIndex, offset)
+			}
+			publicMemory = append(publicMemory, PublicMemoryEntry{Address: address, Value: *value})
+		}
+	}
+	return publicMemory, nil
+}
+
+// Dictionaries gives the state of the dictionaries created during the run: the data of
+// every dictionary and, separately, the default value of the default dictionaries,
+// both keyed by the segment index of the dictionary
+func (runner *ZeroRunner) Dictionaries() (map[uint64]map[fp.Element]mem.MemoryValue, map[uint64]mem.MemoryValue) {
+	dictionaryManager, ok := runner.hintrunner.ZeroDictionaryManager()
+	if !ok {
+		return map[uint64]map[fp.Element]mem.MemoryValue{}, map[uint64]mem.MemoryValue{}
+	}
+	return dictionaryManager.DumpAll(), dictionaryManager.DefaultValues()
+}
+
+// AccessedAddresses gives the offsets of the memory cells read or written during the
+// last run, segment by segment. The accesses are only tracked with the TrackAccesses option
+func (runner *ZeroRunner) AccessedAddresses() (map[uint64]map[uint64]struct{}, error) {
+	if !runner.trackAccesses {
+		return nil, errors.New("the memory accesses are only tracked with the TrackAccesses option")
+	}
+	if runner.vm == nil {
+		return nil, errors.New("cannot get the memory accesses of an uninitialized runner")
+	}
+	return runner.vm.Memory.AccessedAddresses(), nil
+}
+
 func (runner *ZeroRunner) pc() mem.MemoryAddress {
 	return runner.vm.Context.Pc
 }
@@ -383,25 +786,50 @@ func (runner *ZeroRunner) steps() uint64 {
 	return runner.vm.Step
 }
 
-// Gives the output of the last run. Panics if there hasn't
-// been any runs yet.
-func (runner *ZeroRunner) Output() []*fp.Element {
+// BuiltinBase returns the base address of the segment of the builtin named `name`.
+// The builtin segments are allocated right after the program and execution segments,
+// in the order of the layout builtins, whether the program uses them or not
+func (runner *ZeroRunner) BuiltinBase(name string) (mem.MemoryAddress, error) {
+	if runner.vm == nil {
+		return mem.UnknownAddress, errors.New("cannot get a builtin base from an uninitialized runner")
+	}
+
+	for i, segment := range runner.vm.Memory.Segments {
+		if segment.BuiltinRunner.String() == name {
+			return mem.MemoryAddress{SegmentIndex: uint64(i), Offset: 0}, nil
+		}
+	}
+	return mem.UnknownAddress, fmt.Errorf("the layout %s doesn't have the %s builtin", runner.layout.Name, name)
+}
+
+// ErrNoOutputBuiltin is returned by Output when the program doesn't use the output builtin
+var ErrNoOutputBuiltin = errors.New("the program doesn't use the output builtin")
+
+// Gives the output of the last run, i.e. the cells of the output builtin
+// segment in order. Errors if there hasn't been any runs yet or if the
+// program doesn't use the output builtin.
+func (runner *ZeroRunner) Output() ([]fp.Element, error) {
 	if runner.vm == nil {
-		panic("cannot get the output from an uninitialized runner")
+		return nil, errors.New("cannot get the output from an uninitialized runner")
 	}
 
-	output := []*fp.Element{}
+	// Every builtin of the layout gets a segment, the program declares the ones it uses
+	if !slices.Contains(runner.program.Builtins, starknet.Output) {
+		return nil, ErrNoOutputBuiltin
+	}
 	outputSegment, ok := runner.vm.Memory.FindSegmentWithBuiltin("output")
 	if !ok {
-		return output
+		return nil, fmt.Errorf("the layout %s doesn't have the output builtin", runner.layout.Name)
 	}
 
+	output := make([]fp.Element, 0, outputSegment.Len())
 	for offset := uint64(0); offset < outputSegment.Len(); offset++ {
 		value := outputSegment.Peek(offset)
-		// no need to check for an error here since only felts can be written
-		// to the output segment
-		valueFelt, _ := value.FieldElement()
-		output = append(output, valueFelt)
+		valueFelt, err := value.FieldElement()
+		if err != nil {
+			return nil, fmt.Errorf("output offset %d: %w", offset, err)
+		}
+		output = append(output, *valueFelt)
 	}
-	return output
+	return output, nil
 }
@@ -1,6 +1,7 @@
 package zero
 
 import (
+	"fmt"
 	"math"
 	"testing"
 
@@ -8,9 +9,8 @@ import (
 	zero "github.com/NethermindEth/cairo-vm-go/pkg/parsers/zero"
 )
 
-func BenchmarkRunnerWithFibonacci(b *testing.B) {
-	// compiled fibonacci to the millionth
-	compiledJson := []byte(`
+// compiled fibonacci to the millionth
+var fibonacciCompiledJson = []byte(`
         {
             "compiler_version": "0.11.0.2",
             "data": [
@@ -216,9 +216,26 @@ func BenchmarkRunnerWithFibonacci(b *testing.B) {
         }
     `)
 
+func BenchmarkRunnerWithFibonacci(b *testing.B) {
+	benchmarkRunnerWithFibonacci(b, RunnerConfig{ProofMode: true, MaxSteps: math.MaxUint64, Layout: "plain"})
+}
+
+// Compares the execution of the same program with and without collecting its trace.
+// Each step of the trace holds a 32 bytes context, and the growth of the trace slice over
+// the ~ 9M steps of this program accounts for ~ 1.2GB of allocations (~ 2.16GB/op against ~ 0.96GB/op)
+func BenchmarkRunnerWithFibonacciCollectTrace(b *testing.B) {
+	benchmarkRunnerWithFibonacci(b, RunnerConfig{CollectTrace: true, MaxSteps: math.MaxUint64, Layout: "plain"})
+}
+
+func BenchmarkRunnerWithFibonacciNoTrace(b *testing.B) {
+	benchmarkRunnerWithFibonacci(b, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "plain"})
+}
+
+func benchmarkRunnerWithFibonacci(b *testing.B, config RunnerConfig) {
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		cairoZeroJson, err := zero.ZeroProgramFromJSON(compiledJson)
+		cairoZeroJson, err := zero.ZeroProgramFromJSON(fibonacciCompiledJson)
 		if err != nil {
 			panic(err)
 		}
@@ -228,12 +245,12 @@ func BenchmarkRunnerWithFibonacci(b *testing.B) {
 			panic(err)
 		}
 
-		hints, err := hintrunner.GetZeroHints(cairoZeroJson)
+		hints, err := hintrunner.GetZeroHints(cairoZeroJson, nil)
 		if err != nil {
 			panic(err)
 		}
 
-		runner, err := NewRunner(program, hints, true, math.MaxUint64, "plain")
+		runner, err := NewRunner(program, hints, config)
 		if err != nil {
 			panic(err)
 		}
@@ -244,3 +261,19 @@ func BenchmarkRunnerWithFibonacci(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkFinalizeBuiltins(b *testing.B) {
+	for _, workers := range []int{1, 2} {
+		b.Run(fmt.Sprintf("workers_%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				runner := createBuiltinInputsRunner(b, workers, 256)
+				b.StartTimer()
+
+				if err := runner.FinalizeBuiltins(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
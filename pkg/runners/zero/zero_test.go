@@ -1,14 +1,20 @@
 package zero
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/assembler"
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/hinter"
+	zerohint "github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/zero"
 	sn "github.com/NethermindEth/cairo-vm-go/pkg/parsers/starknet"
+	zero "github.com/NethermindEth/cairo-vm-go/pkg/parsers/zero"
+	"github.com/NethermindEth/cairo-vm-go/pkg/utils"
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm"
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/builtins"
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 	pedersenhash "github.com/consensys/gnark-crypto/ecc/stark-curve/pedersen-hash"
@@ -27,7 +33,7 @@ func TestSimpleProgram(t *testing.T) {
     `)
 
 	hints := make(map[uint64][]hinter.Hinter)
-	runner, err := NewRunner(program, hints, false, math.MaxUint64, "plain")
+	runner, err := NewRunner(program, hints, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "plain"})
 	require.NoError(t, err)
 
 	endPc, err := runner.InitializeMainEntrypoint()
@@ -74,7 +80,7 @@ func TestStepLimitExceeded(t *testing.T) {
     `)
 
 	hints := make(map[uint64][]hinter.Hinter)
-	runner, err := NewRunner(program, hints, false, 3, "plain")
+	runner, err := NewRunner(program, hints, RunnerConfig{MaxSteps: 3, Layout: "plain"})
 	require.NoError(t, err)
 
 	endPc, err := runner.InitializeMainEntrypoint()
@@ -112,6 +118,25 @@ func TestStepLimitExceeded(t *testing.T) {
 	assert.Equal(t, uint64(3), runner.steps())
 }
 
+func TestMaxSegmentSizeExceeded(t *testing.T) {
+	program := createProgram(`
+        [ap] = 2;
+        [ap + 1] = 3;
+        [ap + 2] = 5;
+        ret;
+    `)
+
+	hints := make(map[uint64][]hinter.Hinter)
+	runner, err := NewRunner(program, hints, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "plain", MaxSegmentSize: 4})
+	require.NoError(t, err)
+
+	err = runner.Run()
+	require.ErrorContains(t, err, "exceeds the maximum segment size of 4")
+
+	// return fp and next pc are followed by the two values that fit
+	assert.Equal(t, uint64(4), runner.vm.Memory.SegmentSizes()[vm.ExecutionSegment])
+}
+
 func TestStepLimitExceededProofMode(t *testing.T) {
 	program := createProgram(`
         [ap] = 2;
@@ -133,7 +158,7 @@ func TestStepLimitExceededProofMode(t *testing.T) {
 		// when maxstep = 6, it fails executing the extra step required by proof mode
 		// when maxstep = 7, it fails trying to get the trace to be a power of 2
 		hints := make(map[uint64][]hinter.Hinter)
-		runner, err := NewRunner(program, hints, true, uint64(maxstep), "plain")
+		runner, err := NewRunner(program, hints, RunnerConfig{ProofMode: true, MaxSteps: uint64(maxstep), Layout: "plain"})
 		require.NoError(t, err)
 
 		err = runner.Run()
@@ -169,6 +194,757 @@ func TestStepLimitExceededProofMode(t *testing.T) {
 	}
 }
 
+func TestProof(t *testing.T) {
+	program := createProgramWithBuiltins(`
+        ap += 1;
+        [ap] = 7, ap++;
+        [ap - 1] = [[ap - 2]];
+        jmp rel 0;
+    `, sn.Output)
+	// __start__ skips the output pointer and __end__ loops forever
+	program.Labels = map[string]uint64{
+		"__start__": 0,
+		"__end__":   5,
+	}
+
+	runner, err := NewRunner(program, nil, RunnerConfig{ProofMode: true, MaxSteps: math.MaxUint64, Layout: "small"})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+
+	proof, err := runner.Proof()
+	require.NoError(t, err)
+
+	// the run is padded to a power of two number of steps
+	assert.Equal(t, uint64(len(proof.Trace)), runner.steps())
+	assert.Equal(t, utils.NextPowerOfTwo(runner.steps()), runner.steps())
+
+	// the relocated memory starts at index 1
+	_, maxMemoryUsed := runner.vm.Memory.RelocationOffsets()
+	assert.Equal(t, int(maxMemoryUsed), len(proof.Memory))
+	assert.Nil(t, proof.Memory[0])
+
+	// 7 program cells, 3 initial stack cells and 1 output cell
+	pages := make(map[uint64]bool)
+	for _, entry := range proof.PublicMemory {
+		pages[entry.Page] = true
+	}
+	assert.Len(t, proof.PublicMemory, 11)
+	assert.Len(t, pages, 1)
+	outputEntry := proof.PublicMemory[len(proof.PublicMemory)-1]
+	assert.Equal(t, *new(fp.Element).SetUint64(7), outputEntry.Value)
+	assert.Equal(t, proof.Memory[outputEntry.Address], &outputEntry.Value)
+
+	assert.Equal(t, uint64(1), proof.BuiltinSegmentSizes["output"])
+	assert.Len(t, proof.BuiltinSegmentSizes, len(runner.layout.Builtins))
+}
+
+func TestProofPaddingError(t *testing.T) {
+	program := createProgramWithBuiltins(`
+        ap += 1;
+        [ap] = 7, ap++;
+        [ap - 1] = [[ap - 2]];
+        jmp rel 0;
+    `, sn.Output)
+	program.Labels = map[string]uint64{
+		"__start__": 0,
+		"__end__":   5,
+	}
+
+	// the padding needed by the layout goes past the step limit
+	runner, err := NewRunner(program, nil, RunnerConfig{ProofMode: true, MaxSteps: 16, Layout: "small"})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+
+	_, err = runner.Proof()
+	require.ErrorContains(t, err, "cannot end run")
+}
+
+func TestProofModeInitialStack(t *testing.T) {
+	program := createProgramWithBuiltins(`
+        [ap] = 7, ap++;
+        ret;
+        jmp rel 0;
+    `, sn.Output)
+	program.Entrypoints = map[string]uint64{"main": 0}
+
+	// outside of proof mode, the builtin pointers are followed by the return fp and pc
+	runner, err := NewRunner(program, nil, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "small"})
+	require.NoError(t, err)
+	end, err := runner.InitializeMainEntrypoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), runner.vm.Context.Ap)
+	assert.Equal(t, uint64(3), runner.vm.Context.Fp)
+	assert.Equal(t, memory.MemoryAddress{SegmentIndex: vm.ProgramSegment, Offset: 0}, runner.vm.Context.Pc)
+	assert.NotEqual(t, uint64(vm.ProgramSegment), end.SegmentIndex)
+	assert.Zero(t, runner.executionPublicMemorySize)
+
+	// in proof mode, a dummy fp and pc come first and the run goes from __start__ to __end__
+	program.Labels = map[string]uint64{
+		"__start__": 3,
+		"__end__":   3,
+	}
+	runner, err = NewRunner(program, nil, RunnerConfig{ProofMode: true, MaxSteps: math.MaxUint64, Layout: "small"})
+	require.NoError(t, err)
+	end, err = runner.InitializeMainEntrypoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), runner.vm.Context.Ap)
+	assert.Equal(t, uint64(2), runner.vm.Context.Fp)
+	assert.Equal(t, memory.MemoryAddress{SegmentIndex: vm.ProgramSegment, Offset: 3}, runner.vm.Context.Pc)
+	assert.Equal(t, memory.MemoryAddress{SegmentIndex: vm.ProgramSegment, Offset: 3}, end)
+	assert.Equal(t, uint64(3), runner.executionPublicMemorySize)
+
+	dummyFp, err := runner.vm.Memory.Read(vm.ExecutionSegment, 0)
+	require.NoError(t, err)
+	assert.Equal(t, memory.MemoryValueFromSegmentAndOffset(vm.ProgramSegment, len(program.Bytecode)+2), dummyFp)
+
+	// the proof mode labels are checked when the runner is created
+	program.Labels = map[string]uint64{"__start__": 3}
+	_, err = NewRunner(program, nil, RunnerConfig{ProofMode: true, MaxSteps: math.MaxUint64, Layout: "small"})
+	require.ErrorContains(t, err, "end label not found")
+}
+
+func TestBuiltinBase(t *testing.T) {
+	program := createProgramWithBuiltins(`
+        ap += 1;
+        jmp rel 0;
+    `, sn.Output, sn.RangeCheck)
+	program.Labels = map[string]uint64{
+		"__start__": 0,
+		"__end__":   2,
+	}
+
+	runner, err := NewRunner(program, nil, RunnerConfig{ProofMode: true, MaxSteps: math.MaxUint64, Layout: "small"})
+	require.NoError(t, err)
+	_, err = runner.BuiltinBase(builtins.OutputName)
+	require.ErrorContains(t, err, "uninitialized runner")
+
+	_, err = runner.InitializeMainEntrypoint()
+	require.NoError(t, err)
+
+	// the small layout builtins come right after the program and execution segments, in order
+	for i, name := range []string{builtins.OutputName, builtins.PedersenName, builtins.RangeCheckName, builtins.ECDSAName} {
+		base, err := runner.BuiltinBase(name)
+		require.NoError(t, err)
+		assert.Equal(t, memory.MemoryAddress{SegmentIndex: uint64(i + 2), Offset: 0}, base)
+	}
+
+	// only the bases of the builtins used by the program are on the initial stack,
+	// after the dummy fp and pc
+	for offset, name := range map[uint64]string{2: builtins.OutputName, 3: builtins.RangeCheckName} {
+		base, err := runner.BuiltinBase(name)
+		require.NoError(t, err)
+		stackValue, err := runner.vm.Memory.Read(vm.ExecutionSegment, offset)
+		require.NoError(t, err)
+		assert.Equal(t, memory.MemoryValueFromMemoryAddress(&base), stackValue)
+	}
+
+	_, err = runner.BuiltinBase(builtins.BitwiseName)
+	require.ErrorContains(t, err, "the layout small doesn't have the bitwise builtin")
+}
+
+func TestProofWithoutProofMode(t *testing.T) {
+	runner := createRunner(`
+        [ap] = 1;
+        ret;
+    `, "plain")
+	require.NoError(t, runner.Run())
+
+	_, err := runner.Proof()
+	require.ErrorContains(t, err, "proof artifacts can only be built in proof mode")
+}
+
+func TestProofModeCompiledProgram(t *testing.T) {
+	// __start__ calls main and __end__ loops forever, like the wrapper added
+	// by `--proof_mode`
+	program := createProgram(`
+        call rel 4;
+        jmp rel 0;
+        [ap] = 5, ap++;
+        ret;
+    `)
+	program.Labels = map[string]uint64{
+		"__start__": 0,
+		"__end__":   2,
+	}
+	require.True(t, program.IsProofMode())
+
+	runner, err := NewRunner(program, nil, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "plain"})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+
+	// the run stops at __end__, without being padded
+	assert.Equal(t, memory.MemoryAddress{SegmentIndex: vm.ProgramSegment, Offset: 2}, runner.pc())
+	assert.Equal(t, uint64(3), runner.steps())
+
+	executionSegment := runner.vm.Memory.Segments[vm.ExecutionSegment]
+	assert.Equal(
+		t,
+		createSegment(
+			// the dummy last fp and pc
+			&memory.MemoryAddress{SegmentIndex: vm.ProgramSegment, Offset: uint64(len(program.Bytecode) + 2)},
+			0,
+			// fp and return pc pushed by the call to main
+			&memory.MemoryAddress{SegmentIndex: vm.ExecutionSegment, Offset: 2},
+			&memory.MemoryAddress{SegmentIndex: vm.ProgramSegment, Offset: 2},
+			5,
+		),
+		trimmedSegment(executionSegment),
+	)
+}
+
+func TestHintsFireAtTheirPc(t *testing.T) {
+	program := createProgram(`
+        [ap] = 1, ap++;
+        [ap] = [ap - 1], ap++;
+        [ap] = [ap - 1], ap++;
+        [ap] = [ap - 1], ap++;
+        [ap + 1] = [ap] + [ap - 1];
+        ret;
+    `)
+
+	// The instruction at pc 5 needs [ap] to be known, so the hint
+	// has to be executed right before it
+	var hintSteps []uint64
+	var hintPcs []uint64
+	hint := &zerohint.GenericZeroHinter{
+		Name: "WriteAp",
+		Op: func(vm *vm.VirtualMachine, _ *hinter.HintRunnerContext) error {
+			hintSteps = append(hintSteps, vm.Step)
+			hintPcs = append(hintPcs, vm.Context.Pc.Offset)
+			apAddr := vm.Context.AddressAp()
+			value := memory.MemoryValueFromInt(41)
+			return vm.Memory.WriteToAddress(&apAddr, &value)
+		},
+	}
+
+	hints := map[uint64][]hinter.Hinter{5: {hint}}
+	runner, err := NewRunner(program, hints, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "plain"})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+
+	// 4 instructions, at pcs 0, 2, 3 and 4, are executed before the one at pc 5
+	assert.Equal(t, []uint64{4}, hintSteps)
+	assert.Equal(t, []uint64{5}, hintPcs)
+
+	executionSegment := runner.vm.Memory.Segments[vm.ExecutionSegment]
+	assert.Equal(t, memory.MemoryValueFromInt(41), executionSegment.Peek(6))
+	assert.Equal(t, memory.MemoryValueFromInt(42), executionSegment.Peek(7))
+}
+
+func TestHintScopeAcrossPcs(t *testing.T) {
+	program := createProgram(`
+        [ap] = 1, ap++;
+        [ap] = [ap - 1], ap++;
+        [ap] = [ap - 1] + 1, ap++;
+        ret;
+    `)
+
+	// the scope entered at pc 0 is still the current one when the hint at pc 2
+	// runs, after the instruction at pc 0 executed
+	enterScope := &zerohint.GenericZeroHinter{
+		Name: "EnterScope",
+		Op: func(vm *vm.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+			ctx.ScopeManager.EnterScope(map[string]any{"n": uint64(41)})
+			return nil
+		},
+	}
+	var scopedValue any
+	readAndExitScope := &zerohint.GenericZeroHinter{
+		Name: "ReadAndExitScope",
+		Op: func(vm *vm.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+			value, err := ctx.ScopeManager.GetVariableValue("n")
+			if err != nil {
+				return err
+			}
+			scopedValue = value
+			return ctx.ScopeManager.ExitScope()
+		},
+	}
+	var exitedValueErr error
+	readExitedScope := &zerohint.GenericZeroHinter{
+		Name: "ReadExitedScope",
+		Op: func(vm *vm.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+			_, exitedValueErr = ctx.ScopeManager.GetVariableValue("n")
+			return nil
+		},
+	}
+
+	hints := map[uint64][]hinter.Hinter{
+		0: {enterScope},
+		2: {readAndExitScope},
+		5: {readExitedScope},
+	}
+	runner, err := NewRunner(program, hints, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "plain"})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+
+	assert.Equal(t, uint64(41), scopedValue)
+	assert.ErrorContains(t, exitedValueErr, "variable n not found in current scope")
+}
+
+func TestLayoutBuiltins(t *testing.T) {
+	program := createProgramWithBuiltins(`
+        [ap] = 14, ap++;
+        [ap] = 7, ap++;
+        [ap - 2] = [[fp - 3]];
+        [ap - 1] = [[fp - 3] + 1];
+        [ap] = [[fp - 3] + 2];
+        ret;
+    `, sn.Output, sn.Bitwise)
+	hints := make(map[uint64][]hinter.Hinter)
+
+	builtinSegments := func(runner *ZeroRunner) []string {
+		names := []string{}
+		for _, segment := range runner.vm.Memory.Segments {
+			if _, ok := segment.BuiltinRunner.(*memory.NoBuiltin); !ok {
+				names = append(names, segment.BuiltinRunner.String())
+			}
+		}
+		return names
+	}
+
+	runner, err := NewRunner(program, hints, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "recursive"})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+	assert.Equal(t, []string{"output", "pedersen", "range_check", "bitwise"}, builtinSegments(&runner))
+	bitwiseSegment, ok := runner.vm.Memory.FindSegmentWithBuiltin("bitwise")
+	require.True(t, ok)
+	assert.Equal(t, memory.MemoryValueFromInt(6), bitwiseSegment.Peek(2))
+
+	runner, err = NewRunner(program, hints, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "all_cairo"})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+	assert.Equal(t,
+		[]string{"output", "pedersen", "range_check", "ecdsa", "bitwise", "ec_op", "keccak", "poseidon"},
+		builtinSegments(&runner),
+	)
+
+	_, err = NewRunner(program, hints, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "small"})
+	require.ErrorContains(t, err, "builtin bitwise not found in the layout: small")
+
+	_, err = NewRunner(program, hints, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "dynamic"})
+	require.ErrorContains(t, err, "Layout dynamic not found")
+}
+
+func TestProgramInputInitialDict(t *testing.T) {
+	program := createProgram(`
+        [ap] = [ap], ap++;
+        ret;
+    `)
+
+	// dict_new writes the new dictionary pointer at [ap]
+	dictNewHint, err := zerohint.GetHintFromCode(&zero.ZeroProgram{}, zero.Hint{
+		Code: "if '__dict_manager' not in globals():\n    from starkware.cairo.common.dict import DictManager\n    __dict_manager = DictManager()\n\nmemory[ap] = __dict_manager.new_dict(segments, initial_dict)\ndel initial_dict",
+	}, 0, nil)
+	require.NoError(t, err)
+
+	dictValues := map[uint64]memory.MemoryValue{}
+	var initialDictErr error
+	readDict := &zerohint.GenericZeroHinter{
+		Name: "ReadDict",
+		Op: func(vm *vm.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+			dictionaryManager, ok := ctx.ScopeManager.GetZeroDictionaryManager()
+			if !ok {
+				return fmt.Errorf("__dict_manager not in scope")
+			}
+			dictAddr, err := vm.Memory.ReadFromAddressAsAddress(&memory.MemoryAddress{SegmentIndex: 1, Offset: vm.Context.Ap - 1})
+			if err != nil {
+				return err
+			}
+			for _, key := range []uint64{1, 2} {
+				dictValues[key], err = dictionaryManager.At(dictAddr, *new(fp.Element).SetUint64(key))
+				if err != nil {
+					return err
+				}
+			}
+			_, initialDictErr = ctx.ScopeManager.GetVariableValueFromAnyScope("initial_dict")
+			return nil
+		},
+	}
+
+	// the dictionary is created inside a scope entered by the program
+	enterScope := &zerohint.GenericZeroHinter{
+		Name: "EnterScope",
+		Op: func(_ *vm.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+			ctx.ScopeManager.EnterScope(map[string]any{})
+			return nil
+		},
+	}
+
+	hints := map[uint64][]hinter.Hinter{
+		0: {enterScope, dictNewHint},
+		1: {readDict},
+	}
+	runner, err := NewRunner(program, hints, RunnerConfig{
+		MaxSteps: math.MaxUint64,
+		Layout:   "plain",
+		ProgramInput: ProgramInput{
+			InitialDict: map[fp.Element]fp.Element{
+				*new(fp.Element).SetUint64(1): *new(fp.Element).SetUint64(10),
+				*new(fp.Element).SetUint64(2): *new(fp.Element).SetUint64(20),
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+
+	assert.Equal(t, map[uint64]memory.MemoryValue{
+		1: memory.MemoryValueFromUint(uint64(10)),
+		2: memory.MemoryValueFromUint(uint64(20)),
+	}, dictValues)
+	// dict_new consumes initial_dict
+	assert.ErrorContains(t, initialDictErr, "variable initial_dict not found in any scope")
+}
+
+func TestProgramInputInitialDefaultDict(t *testing.T) {
+	program := createProgram(`
+        [ap] = 7, ap++;
+        [ap] = [ap], ap++;
+        ret;
+    `)
+
+	// default_dict_new reads its default value at [ap - 1] and writes the new
+	// dictionary pointer at [ap]
+	defaultDictNewHint, err := zerohint.GetHintFromCode(&zero.ZeroProgram{
+		Identifiers: map[string]*zero.Identifier{
+			"__main__.default_value": {
+				IdentifierType: "reference",
+				References:     []zero.Reference{{Pc: 2, Value: "[cast(ap + (-1), felt*)]"}},
+			},
+		},
+	}, zero.Hint{
+		Code: "if '__dict_manager' not in globals():\n    from starkware.cairo.common.dict import DictManager\n    __dict_manager = DictManager()\n\nmemory[ap] = __dict_manager.new_default_dict(segments, ids.default_value)",
+		FlowTrackingData: zero.FlowTrackingData{
+			ReferenceIds: map[string]uint64{"__main__.default_value": 0},
+		},
+	}, 2, nil)
+	require.NoError(t, err)
+
+	dictValues := map[uint64]memory.MemoryValue{}
+	var initialDefaultDictErr error
+	readDict := &zerohint.GenericZeroHinter{
+		Name: "ReadDict",
+		Op: func(vm *vm.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+			dictionaryManager, ok := ctx.ScopeManager.GetZeroDictionaryManager()
+			if !ok {
+				return fmt.Errorf("__dict_manager not in scope")
+			}
+			dictAddr, err := vm.Memory.ReadFromAddressAsAddress(&memory.MemoryAddress{SegmentIndex: 1, Offset: vm.Context.Ap - 1})
+			if err != nil {
+				return err
+			}
+			for _, key := range []uint64{1, 2} {
+				dictValues[key], err = dictionaryManager.At(dictAddr, *new(fp.Element).SetUint64(key))
+				if err != nil {
+					return err
+				}
+			}
+			_, initialDefaultDictErr = ctx.ScopeManager.GetVariableValueFromAnyScope("__initial_default_dict")
+			return nil
+		},
+	}
+
+	// library code usually creates the dictionary inside a scope of its own
+	enterScope := &zerohint.GenericZeroHinter{
+		Name: "EnterScope",
+		Op: func(_ *vm.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+			ctx.ScopeManager.EnterScope(map[string]any{})
+			return nil
+		},
+	}
+
+	hints := map[uint64][]hinter.Hinter{
+		2: {enterScope, defaultDictNewHint},
+		3: {readDict},
+	}
+	runner, err := NewRunner(program, hints, RunnerConfig{
+		MaxSteps: math.MaxUint64,
+		Layout:   "plain",
+		ProgramInput: ProgramInput{
+			InitialDefaultDict: map[fp.Element]fp.Element{
+				*new(fp.Element).SetUint64(1): *new(fp.Element).SetUint64(10),
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+
+	assert.Equal(t, map[uint64]memory.MemoryValue{
+		// the seeded key, then a missing key served from the default value
+		1: memory.MemoryValueFromUint(uint64(10)),
+		2: memory.MemoryValueFromUint(uint64(7)),
+	}, dictValues)
+	// default_dict_new consumes __initial_default_dict
+	assert.ErrorContains(t, initialDefaultDictErr, "variable __initial_default_dict not found in any scope")
+}
+
+func TestAccessedAddresses(t *testing.T) {
+	program := createProgram(`
+        [ap] = 2, ap++;
+        [ap] = [ap - 1] + 1;
+        ret;
+    `)
+
+	runner, err := NewRunner(program, nil, RunnerConfig{
+		MaxSteps: math.MaxUint64,
+		Layout:   "plain",
+	})
+	require.NoError(t, err)
+	_, err = runner.AccessedAddresses()
+	require.ErrorContains(t, err, "only tracked with the TrackAccesses option")
+
+	runner, err = NewRunner(program, nil, RunnerConfig{
+		MaxSteps:      math.MaxUint64,
+		Layout:        "plain",
+		TrackAccesses: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+
+	accesses, err := runner.AccessedAddresses()
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]map[uint64]struct{}{
+		// the three instructions, the first two with an immediate
+		vm.ProgramSegment: {0: {}, 1: {}, 2: {}, 3: {}, 4: {}},
+		// the return fp and pc, then the two values pushed
+		vm.ExecutionSegment: {0: {}, 1: {}, 2: {}, 3: {}},
+	}, accesses)
+}
+
+func TestHintedRangeCheckCells(t *testing.T) {
+	// main(range_check_ptr) returns range_check_ptr + 2 once the hint filled
+	// [range_check_ptr] and [range_check_ptr + 1], as split_felt does
+	program := createProgramWithBuiltins(`
+        [ap] = [fp - 3] + 2, ap++;
+        ret;
+    `, sn.RangeCheck)
+
+	rangeCheckPtr := hinter.Deref{Deref: hinter.FpCellRef(-3)}
+	splitFelt := &zerohint.GenericZeroHinter{
+		Name: "SplitFelt",
+		Op: func(vm *vm.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+			for i, limb := range []uint64{7, 1} {
+				limbAddr, err := hinter.DoubleDeref{Deref: rangeCheckPtr, Offset: int16(i)}.GetAddress(vm)
+				if err != nil {
+					return err
+				}
+				value := memory.MemoryValueFromUint(limb)
+				if err := vm.Memory.WriteToAddress(&limbAddr, &value); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	runner, err := NewRunner(program, map[uint64][]hinter.Hinter{0: {splitFelt}}, RunnerConfig{
+		MaxSteps: math.MaxUint64,
+		Layout:   "small",
+	})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+
+	rangeCheck, ok := runner.vm.Memory.FindSegmentWithBuiltin(builtins.RangeCheckName)
+	require.True(t, ok)
+	requireEqualSegments(t, createSegment(7, 1), rangeCheck)
+
+	// the hint leaves range_check_ptr as is, the Cairo code moves it past the hinted cells
+	returnedPtr, err := runner.vm.Memory.ReadFromAddressAsAddress(
+		&memory.MemoryAddress{SegmentIndex: vm.ExecutionSegment, Offset: runner.vm.Context.Ap - 1},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, rangeCheck.Len(), returnedPtr.Offset)
+}
+
+func TestDictionaries(t *testing.T) {
+	program := createProgram(`
+        [ap] = [ap], ap++;
+        ret;
+    `)
+
+	// without dictionary hints, there is no dictionary
+	runner, err := NewRunner(createProgram("ret;"), nil, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "plain"})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+	data, defaultValues := runner.Dictionaries()
+	assert.Empty(t, data)
+	assert.Empty(t, defaultValues)
+
+	dictNewHint, err := zerohint.GetHintFromCode(&zero.ZeroProgram{}, zero.Hint{
+		Code: "if '__dict_manager' not in globals():\n    from starkware.cairo.common.dict import DictManager\n    __dict_manager = DictManager()\n\nmemory[ap] = __dict_manager.new_dict(segments, initial_dict)\ndel initial_dict",
+	}, 0, nil)
+	require.NoError(t, err)
+	runner, err = NewRunner(program, map[uint64][]hinter.Hinter{0: {dictNewHint}}, RunnerConfig{
+		MaxSteps: math.MaxUint64,
+		Layout:   "plain",
+		ProgramInput: ProgramInput{
+			InitialDict: map[fp.Element]fp.Element{
+				*new(fp.Element).SetUint64(1): *new(fp.Element).SetUint64(10),
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+
+	dictAddr, err := runner.vm.Memory.ReadFromAddressAsAddress(&memory.MemoryAddress{SegmentIndex: vm.ExecutionSegment, Offset: 2})
+	require.NoError(t, err)
+	data, defaultValues = runner.Dictionaries()
+	assert.Equal(t, map[uint64]map[fp.Element]memory.MemoryValue{
+		dictAddr.SegmentIndex: {*new(fp.Element).SetUint64(1): memory.MemoryValueFromUint(uint64(10))},
+	}, data)
+	assert.Empty(t, defaultValues)
+}
+
+func TestEntryPointByName(t *testing.T) {
+	// add(x, y) -> (res) returns x + y, after a main which isn't run
+	program := createProgram(`
+        [ap] = 1, ap++;
+        ret;
+        [ap] = [fp - 4] + [fp - 3], ap++;
+        ret;
+    `)
+	program.Entrypoints["add"] = 3
+	program.ArgsSizes = map[string]uint64{"main": 0, "add": 2}
+
+	config := RunnerConfig{
+		MaxSteps:   math.MaxUint64,
+		Layout:     "plain",
+		EntryPoint: "add",
+		ProgramInput: ProgramInput{
+			Arguments: []fp.Element{*new(fp.Element).SetUint64(5), *new(fp.Element).SetUint64(37)},
+		},
+	}
+	runner, err := NewRunner(program, nil, config)
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+
+	res, err := runner.vm.Memory.ReadFromAddressAsElement(
+		&memory.MemoryAddress{SegmentIndex: vm.ExecutionSegment, Offset: runner.vm.Context.Ap - 1},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, *new(fp.Element).SetUint64(42), res)
+
+	config.ProgramInput.Arguments = config.ProgramInput.Arguments[:1]
+	_, err = NewRunner(program, nil, config)
+	require.ErrorContains(t, err, "entry point add expects 2 arguments, got 1")
+
+	config.EntryPoint = "sub"
+	_, err = NewRunner(program, nil, config)
+	require.ErrorContains(t, err, "entry point sub not found in the program")
+
+	config.EntryPoint = "main"
+	config.ProofMode = true
+	_, err = NewRunner(program, nil, config)
+	require.ErrorContains(t, err, "an entry point cannot be run in proof mode")
+}
+
+func TestRunWithContext(t *testing.T) {
+	program := createProgram(`
+        [ap] = 2, ap++;
+        jmp rel 0;
+    `)
+	config := RunnerConfig{MaxSteps: math.MaxUint64, Layout: "plain", ContextCheckInterval: 100}
+
+	// the program loops forever, the run only stops once the context is cancelled
+	runner, err := NewRunner(program, nil, config)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	err = runner.RunWithContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// the partial state of the run is kept, and the context was checked on an interval step
+	assert.Greater(t, runner.steps(), uint64(0))
+	assert.Zero(t, runner.steps()%100)
+	assert.Equal(t, uint64(2), runner.pc().Offset)
+
+	// an already expired context stops the run before its first step
+	runner, err = NewRunner(program, nil, config)
+	require.NoError(t, err)
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	err = runner.RunWithContext(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, runner.steps())
+}
+
+func TestCollectTrace(t *testing.T) {
+	program := createProgram(`
+        [ap] = 2, ap++;
+        [ap] = 3, ap++;
+        [ap] = [ap - 1] * [ap - 2], ap++;
+        [ap] = [ap - 1] + 1;
+        ret;
+    `)
+
+	runWith := func(collectTrace bool) ZeroRunner {
+		hints := make(map[uint64][]hinter.Hinter)
+		runner, err := NewRunner(program, hints, RunnerConfig{
+			CollectTrace: collectTrace,
+			MaxSteps:     math.MaxUint64,
+			Layout:       "plain",
+		})
+		require.NoError(t, err)
+		require.NoError(t, runner.Run())
+		return runner
+	}
+
+	traced := runWith(true)
+	untraced := runWith(false)
+
+	assert.Equal(t, traced.vm.Context, untraced.vm.Context)
+	assert.Equal(t, traced.vm.Step, untraced.vm.Step)
+	assert.Equal(t, traced.vm.Memory.Segments, untraced.vm.Memory.Segments)
+
+	assert.Len(t, traced.vm.Trace, int(traced.vm.Step))
+	assert.Empty(t, untraced.vm.Trace)
+
+	trace, err := traced.vm.ExecutionTrace()
+	require.NoError(t, err)
+	assert.Len(t, trace, int(traced.vm.Step))
+
+	_, err = untraced.vm.ExecutionTrace()
+	require.ErrorContains(t, err, "trace collection is off")
+}
+
+func TestReplayTrace(t *testing.T) {
+	program := createProgram(`
+        [ap] = 2, ap++;
+        [ap] = 3, ap++;
+        [ap] = [ap - 1] * [ap - 2], ap++;
+        [ap] = [ap - 1] + 1;
+        ret;
+    `)
+
+	newRunner := func() ZeroRunner {
+		hints := make(map[uint64][]hinter.Hinter)
+		runner, err := NewRunner(program, hints, RunnerConfig{
+			MaxSteps: math.MaxUint64,
+			Layout:   "plain",
+		})
+		require.NoError(t, err)
+		return runner
+	}
+
+	original := newRunner()
+	original.collectTrace = true
+	require.NoError(t, original.Run())
+	trace, err := original.vm.ExecutionTrace()
+	require.NoError(t, err)
+
+	replay := newRunner()
+	require.NoError(t, replay.ReplayTrace(trace))
+
+	tampered := make([]vm.Trace, len(trace))
+	copy(tampered, trace)
+	tampered[2].Ap++
+	replay = newRunner()
+	require.ErrorContains(t, replay.ReplayTrace(tampered), "trace diverges at step 2")
+
+	replay = newRunner()
+	require.ErrorContains(t, replay.ReplayTrace(trace[:3]), "trace diverges at step 3: expected 3 steps, got 5")
+}
+
 func TestBitwiseBuiltin(t *testing.T) {
 	// bitwise segment ptr is located at fp - 3 (fp - 2 and fp - 1 contain initialization vals)
 	// We first write 16 and 8 to bitwise. Then we read the bitwise result from &, ^ and |
@@ -225,6 +1001,96 @@ func TestBitwiseBuiltinError(t *testing.T) {
 	require.ErrorContains(t, err, "input value at offset 0 is unknown")
 }
 
+func TestFinalizeBuiltins(t *testing.T) {
+	finalize := func(workers int) ZeroRunner {
+		runner := createBuiltinInputsRunner(t, workers, 16)
+		require.NoError(t, runner.FinalizeBuiltins())
+		return runner
+	}
+
+	sequential := finalize(0)
+	bitwise, ok := sequential.vm.Memory.FindSegmentWithBuiltin("bitwise")
+	require.True(t, ok)
+	// 3 & 5, 3 ^ 5 and 3 | 5 for the instance at index 3
+	requireEqualSegments(t, createSegment(3, 5, 1, 6, 7), &memory.Segment{Data: bitwise.Data[15:20], LastIndex: 4})
+	// the instance started last has no output to deduce
+	assert.Equal(t, uint64(16*5+1), bitwise.Len())
+
+	for _, workers := range []int{1, 2, 4} {
+		parallel := finalize(workers)
+		assert.Equal(t, sequential.vm.Memory.Segments, parallel.vm.Memory.Segments, "workers: %d", workers)
+	}
+}
+
+// createBuiltinInputsRunner runs an empty main and then writes the inputs of
+// `instances` bitwise and pedersen instances without reading their outputs,
+// plus the first input of another bitwise instance
+func createBuiltinInputsRunner(tb testing.TB, workers int, instances uint64) ZeroRunner {
+	program := createProgramWithBuiltins("ret;", sn.Pedersen, sn.Bitwise)
+	runner, err := NewRunner(program, make(map[uint64][]hinter.Hinter), RunnerConfig{
+		MaxSteps:       math.MaxUint64,
+		Layout:         "starknet_with_keccak",
+		BuiltinWorkers: workers,
+	})
+	require.NoError(tb, err)
+	require.NoError(tb, runner.Run())
+
+	bitwise, _ := runner.vm.Memory.FindSegmentWithBuiltin("bitwise")
+	pedersen, _ := runner.vm.Memory.FindSegmentWithBuiltin("pedersen")
+	for i := uint64(0); i < instances; i++ {
+		x := memory.MemoryValueFromUint(i)
+		y := memory.MemoryValueFromUint(i + 2)
+		require.NoError(tb, bitwise.Write(5*i, &x))
+		require.NoError(tb, bitwise.Write(5*i+1, &y))
+		require.NoError(tb, pedersen.Write(3*i, &x))
+		require.NoError(tb, pedersen.Write(3*i+1, &y))
+	}
+	x := memory.MemoryValueFromUint(instances)
+	require.NoError(tb, bitwise.Write(5*instances, &x))
+	return runner
+}
+
+func TestSecureRun(t *testing.T) {
+	runner := createRunner(`
+        [ap] = 14, ap++;
+        [ap] = 7, ap++;
+        [ap - 2] = [[fp - 3]];
+        [ap - 1] = [[fp - 3] + 1];
+        [ap] = [[fp - 3] + 2];
+        [ap + 1] = [[fp - 3] + 3];
+        [ap + 2] = [[fp - 3] + 4];
+        ret;
+    `, "starknet_with_keccak", sn.Bitwise)
+	require.NoError(t, runner.Run())
+	require.NoError(t, runner.SecureRun())
+
+	// only x & y is read from the instance, leaving a used size of 3
+	runner = createRunner(`
+        [ap] = 14, ap++;
+        [ap] = 7, ap++;
+        [ap - 2] = [[fp - 3]];
+        [ap - 1] = [[fp - 3] + 1];
+        [ap] = [[fp - 3] + 2];
+        ret;
+    `, "starknet_with_keccak", sn.Bitwise)
+	require.NoError(t, runner.Run())
+	require.NoError(t, runner.SecureRun())
+
+	// a second instance is started right after the first one without being completed
+	runner = createRunner(`
+        [ap] = 14, ap++;
+        [ap] = 7, ap++;
+        [ap - 2] = [[fp - 3]];
+        [ap - 1] = [[fp - 3] + 1];
+        [ap] = [[fp - 3] + 2];
+        [ap - 2] = [[fp - 3] + 5];
+        ret;
+    `, "starknet_with_keccak", sn.Bitwise)
+	require.NoError(t, runner.Run())
+	err := runner.SecureRun()
+	require.ErrorContains(t, err, "builtin bitwise: missing memory cells for bitwise: [6]")
+}
+
 func TestOutputBuiltin(t *testing.T) {
 	// Output builtin is located at fp - 3
 	runner := createRunner(`
@@ -232,16 +1098,118 @@ func TestOutputBuiltin(t *testing.T) {
         [ap] = [[fp - 3]];
         [ap + 1] = 7;
         [ap + 1] = [[fp - 3] + 1];
+        [ap + 2] = 3;
+        [ap + 2] = [[fp - 3] + 2];
         ret;
     `, "small", sn.Output)
 	err := runner.Run()
 	require.NoError(t, err)
 
-	output := runner.Output()
+	output, err := runner.Output()
+	require.NoError(t, err)
+	require.Equal(t, []fp.Element{fp.NewElement(5), fp.NewElement(7), fp.NewElement(3)}, output)
+}
 
-	val1 := fp.NewElement(5)
-	val2 := fp.NewElement(7)
-	require.Equal(t, []*fp.Element{&val1, &val2}, output)
+func TestOutputWithoutOutputBuiltin(t *testing.T) {
+	runner := createRunner(`
+        [ap] = 5;
+        ret;
+    `, "plain")
+
+	_, err := runner.Output()
+	require.ErrorContains(t, err, "uninitialized runner")
+
+	err = runner.Run()
+	require.NoError(t, err)
+
+	_, err = runner.Output()
+	require.ErrorIs(t, err, ErrNoOutputBuiltin)
+
+	// the layout gives the output builtin a segment even if the program doesn't use it
+	runner = createRunner(`
+        [ap] = 5;
+        ret;
+    `, "small")
+	require.NoError(t, runner.Run())
+	_, ok := runner.vm.Memory.FindSegmentWithBuiltin("output")
+	require.True(t, ok)
+
+	_, err = runner.Output()
+	require.ErrorIs(t, err, ErrNoOutputBuiltin)
+}
+
+func TestECDSAPrivateInput(t *testing.T) {
+	// ECDSA builtin is located at fp - 3
+	program := createProgramWithBuiltins(`
+        [ap] = 1735102664668487605176656616876767369909409133946409161569774794110049207117;
+        [ap] = [[fp - 3]];
+        [ap + 1] = 2718;
+        [ap + 1] = [[fp - 3] + 1];
+        [ap + 2] = 1434136217923271508453866209352937660241067429834292808151286080516596591512;
+        [ap + 2] = [[fp - 3] + 2];
+        [ap + 3] = 4242;
+        [ap + 3] = [[fp - 3] + 3];
+        ret;
+    `, sn.ECDSA)
+
+	r1, _ := new(fp.Element).SetString("3086480810278599376317923499561306189851900463386393948998357832163236918254")
+	s1, _ := new(fp.Element).SetString("598673427589502599949712887611119751108407514580626464031881322743364689811")
+	r2, _ := new(fp.Element).SetString("2926129818382058060292797586226983168500087817366526668609240955782226185378")
+	s2, _ := new(fp.Element).SetString("521003974718548443846529896507755021534886469023959948679548204247163493115")
+
+	hint := &zerohint.GenericZeroHinter{
+		Name: "AddSignatures",
+		Op: func(vm *vm.VirtualMachine, _ *hinter.HintRunnerContext) error {
+			segment, ok := vm.Memory.FindSegmentWithBuiltin(builtins.ECDSAName)
+			if !ok {
+				return fmt.Errorf("ecdsa segment not found")
+			}
+			ecdsaRunner := segment.BuiltinRunner.(*builtins.ECDSA)
+			if err := ecdsaRunner.AddSignature(0, r1, s1); err != nil {
+				return err
+			}
+			return ecdsaRunner.AddSignature(2, r2, s2)
+		},
+	}
+
+	hints := map[uint64][]hinter.Hinter{0: {hint}}
+	runner, err := NewRunner(program, hints, RunnerConfig{MaxSteps: math.MaxUint64, Layout: "small"})
+	require.NoError(t, err)
+
+	_, err = runner.ECDSAPrivateInput()
+	require.ErrorContains(t, err, "uninitialized runner")
+
+	require.NoError(t, runner.Run())
+
+	privateInput, err := runner.ECDSAPrivateInput()
+	require.NoError(t, err)
+	require.Len(t, privateInput, 2)
+
+	// The ecdsa segment comes right after the program and execution segments
+	segmentsOffsets, _ := runner.vm.Memory.RelocationOffsets()
+	ecdsaBase := segmentsOffsets[2]
+	require.NotZero(t, ecdsaBase)
+
+	relocatedMemory := runner.vm.RelocateMemory()
+	expected := []struct {
+		pubkey, msg string
+		r, s        *fp.Element
+	}{
+		{"1735102664668487605176656616876767369909409133946409161569774794110049207117", "2718", r1, s1},
+		{"1434136217923271508453866209352937660241067429834292808151286080516596591512", "4242", r2, s2},
+	}
+	for i, entry := range privateInput {
+		require.Equal(t, uint64(i), entry.Index)
+		require.Equal(t, ecdsaBase+uint64(2*i), entry.Address)
+		require.Equal(t, expected[i].pubkey, entry.PubKey.String())
+		require.Equal(t, expected[i].msg, entry.Message.String())
+		require.Equal(t, *expected[i].r, entry.R)
+		require.Equal(t, *expected[i].s, entry.S)
+
+		// The relocated addresses point to the signed public key and message
+		require.Equal(t, &entry.PubKey, relocatedMemory[entry.Address])
+		require.Equal(t, &entry.Message, relocatedMemory[entry.Address+1])
+	}
 }
 
 func TestPedersenBuiltin(t *testing.T) {
@@ -368,7 +1336,7 @@ func createRunner(code string, layoutName string, builtins ...sn.Builtin) ZeroRu
 	program := createProgramWithBuiltins(code, builtins...)
 
 	hints := make(map[uint64][]hinter.Hinter)
-	runner, err := NewRunner(program, hints, false, math.MaxUint64, layoutName)
+	runner, err := NewRunner(program, hints, RunnerConfig{MaxSteps: math.MaxUint64, Layout: layoutName})
 	if err != nil {
 		panic(err)
 	}
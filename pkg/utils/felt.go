@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+// FeltToBytesBE encodes a felt as 32 big-endian bytes
+func FeltToBytesBE(f fp.Element) [32]byte {
+	var b [32]byte
+	fp.BigEndian.PutElement(&b, f)
+	return b
+}
+
+// FeltToBytesLE encodes a felt as 32 little-endian bytes
+func FeltToBytesLE(f fp.Element) [32]byte {
+	var b [32]byte
+	fp.LittleEndian.PutElement(&b, f)
+	return b
+}
+
+// BytesToFeltBE decodes at most 32 big-endian bytes into a felt. It errors
+// if the input is longer or encodes a value that isn't lower than PRIME
+func BytesToFeltBE(b []byte) (fp.Element, error) {
+	if len(b) > fp.Bytes {
+		return fp.Element{}, fmt.Errorf("cannot decode %d bytes into a felt, at most %d are allowed", len(b), fp.Bytes)
+	}
+
+	var padded [32]byte
+	copy(padded[fp.Bytes-len(b):], b)
+	f, err := fp.BigEndian.Element(&padded)
+	if err != nil {
+		return fp.Element{}, fmt.Errorf("0x%x is out of the felt range", b)
+	}
+	return f, nil
+}
+
+// BytesToFeltLE decodes at most 32 little-endian bytes into a felt. It errors
+// if the input is longer or encodes a value that isn't lower than PRIME
+func BytesToFeltLE(b []byte) (fp.Element, error) {
+	if len(b) > fp.Bytes {
+		return fp.Element{}, fmt.Errorf("cannot decode %d bytes into a felt, at most %d are allowed", len(b), fp.Bytes)
+	}
+
+	var padded [32]byte
+	copy(padded[:], b)
+	f, err := fp.LittleEndian.Element(&padded)
+	if err != nil {
+		return fp.Element{}, fmt.Errorf("0x%x (little-endian) is out of the felt range", b)
+	}
+	return f, nil
+}
+
+// FormatFelt writes the canonical representative of a felt in the given base, which
+// must be between 2 and 62. Hexadecimal values are prefixed with `0x`
+func FormatFelt(f fp.Element, base int) string {
+	if base == 16 {
+		return "0x" + f.Text(base)
+	}
+	return f.Text(base)
+}
+
+// FormatFeltSigned writes a felt in decimal, as `-k` when it is closer to PRIME
+// than to zero, i.e. when it is the felt `PRIME - k` for some k < PRIME / 2
+func FormatFeltSigned(f fp.Element) string {
+	var value big.Int
+	f.BigInt(&value)
+	if value.Cmp(new(big.Int).Rsh(fp.Modulus(), 1)) <= 0 {
+		return value.String()
+	}
+	return value.Sub(&value, fp.Modulus()).String()
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeltBytesRoundTrip(t *testing.T) {
+	maxFelt := new(fp.Element).SetInt64(-1)
+	felts := []fp.Element{
+		{},
+		*new(fp.Element).SetUint64(1),
+		*new(fp.Element).SetUint64(0x0102030405060708),
+		*maxFelt,
+	}
+
+	for _, f := range felts {
+		be := FeltToBytesBE(f)
+		decoded, err := BytesToFeltBE(be[:])
+		require.NoError(t, err)
+		require.Equal(t, f, decoded)
+
+		le := FeltToBytesLE(f)
+		decoded, err = BytesToFeltLE(le[:])
+		require.NoError(t, err)
+		require.Equal(t, f, decoded)
+	}
+}
+
+func TestFeltBytesEndianness(t *testing.T) {
+	f := new(fp.Element).SetUint64(0x0102)
+
+	be := FeltToBytesBE(*f)
+	require.Equal(t, byte(0x01), be[30])
+	require.Equal(t, byte(0x02), be[31])
+
+	le := FeltToBytesLE(*f)
+	require.Equal(t, byte(0x02), le[0])
+	require.Equal(t, byte(0x01), le[1])
+
+	// Short inputs are padded with leading zeros in the big-endian order
+	// and with trailing zeros in the little-endian one
+	decoded, err := BytesToFeltBE([]byte{0x01, 0x02})
+	require.NoError(t, err)
+	require.Equal(t, *f, decoded)
+
+	decoded, err = BytesToFeltLE([]byte{0x02, 0x01})
+	require.NoError(t, err)
+	require.Equal(t, *f, decoded)
+}
+
+func TestBytesToFeltInvalid(t *testing.T) {
+	_, err := BytesToFeltBE(make([]byte, 33))
+	require.ErrorContains(t, err, "cannot decode 33 bytes into a felt")
+	_, err = BytesToFeltLE(make([]byte, 33))
+	require.ErrorContains(t, err, "cannot decode 33 bytes into a felt")
+
+	// PRIME itself is the smallest out of range value
+	prime := fp.Modulus().Bytes()
+	_, err = BytesToFeltBE(prime)
+	require.ErrorContains(t, err, "is out of the felt range")
+
+	var primeLE [32]byte
+	for i, b := range prime {
+		primeLE[len(prime)-1-i] = b
+	}
+	_, err = BytesToFeltLE(primeLE[:])
+	require.ErrorContains(t, err, "is out of the felt range")
+}
+
+func TestFormatFelt(t *testing.T) {
+	require.Equal(t, "0", FormatFelt(fp.Element{}, 10))
+	require.Equal(t, "255", FormatFelt(*new(fp.Element).SetUint64(255), 10))
+	require.Equal(t, "0xff", FormatFelt(*new(fp.Element).SetUint64(255), 16))
+	require.Equal(t, "11111111", FormatFelt(*new(fp.Element).SetUint64(255), 2))
+
+	minusOne := *new(fp.Element).SetInt64(-1)
+	require.Equal(t, "0x800000000000011000000000000000000000000000000000000000000000000", FormatFelt(minusOne, 16))
+}
+
+func TestFormatFeltSigned(t *testing.T) {
+	require.Equal(t, "0", FormatFeltSigned(fp.Element{}))
+	require.Equal(t, "42", FormatFeltSigned(*new(fp.Element).SetUint64(42)))
+	require.Equal(t, "-1", FormatFeltSigned(*new(fp.Element).SetInt64(-1)))
+	require.Equal(t, "-42", FormatFeltSigned(*new(fp.Element).SetInt64(-42)))
+
+	// (PRIME - 1) / 2 is the largest value closer to zero, the next one is closer to PRIME
+	half := new(big.Int).Rsh(fp.Modulus(), 1)
+	require.Equal(t, half.String(), FormatFeltSigned(*new(fp.Element).SetBigInt(half)))
+	require.Equal(t, "-"+half.String(), FormatFeltSigned(*new(fp.Element).SetBigInt(new(big.Int).Add(half, big.NewInt(1)))))
+}
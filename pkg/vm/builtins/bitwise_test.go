@@ -39,3 +39,28 @@ func TestBitwise(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", xOrYFelt.Text(16))
 }
+
+func TestBitwisePeekDoesNotDeduce(t *testing.T) {
+	mem := memory.InitializeEmptyMemory()
+	bitwiseAddr := mem.AllocateBuiltinSegment(&Bitwise{})
+
+	x := memory.MemoryValueFromUint(uint64(12))
+	y := memory.MemoryValueFromUint(uint64(10))
+	require.NoError(t, mem.WriteToAddress(&bitwiseAddr, &x))
+	yAddr := memory.MemoryAddress{SegmentIndex: bitwiseAddr.SegmentIndex, Offset: 1}
+	require.NoError(t, mem.WriteToAddress(&yAddr, &y))
+
+	xAndYAddr := memory.MemoryAddress{SegmentIndex: bitwiseAddr.SegmentIndex, Offset: 2}
+	_, ok := mem.PeekKnown(&xAndYAddr)
+	assert.False(t, ok)
+	assert.False(t, mem.KnownValue(xAndYAddr.SegmentIndex, xAndYAddr.Offset))
+
+	xAndY, err := mem.ReadFromAddress(&xAndYAddr)
+	require.NoError(t, err)
+	assert.Equal(t, memory.MemoryValueFromUint(uint64(8)), xAndY)
+
+	// once deduced, the cell is known
+	xAndY, ok = mem.PeekKnown(&xAndYAddr)
+	assert.True(t, ok)
+	assert.Equal(t, memory.MemoryValueFromUint(uint64(8)), xAndY)
+}
@@ -63,3 +63,146 @@ func getBuiltinAllocatedSize(segmentUsedSize uint64, vmCurrentStep uint64, ratio
 	}
 	return allocatedInstances * cellsPerInstance, nil
 }
+
+// instanceLayout describes how many memory cells one instance of a builtin occupies,
+// and how many of them are inputs. The remaining cells are deduced from the inputs.
+type instanceLayout struct {
+	cells      uint64
+	inputCells uint64
+}
+
+// instanceLayouts maps every builtin name to the layout of one of its instances
+var instanceLayouts = map[string]instanceLayout{
+	OutputName:     {cells: 1, inputCells: 1},
+	RangeCheckName: {cells: cellsPerRangeCheck, inputCells: inputCellsPerRangeCheck},
+	PedersenName:   {cells: cellsPerPedersen, inputCells: inputCellsPerPedersen},
+	ECDSAName:      {cells: cellsPerECDSA, inputCells: inputCellsPerECDSA},
+	KeccakName:     {cells: cellsPerKeccak, inputCells: inputCellsPerKeccak},
+	BitwiseName:    {cells: cellsPerBitwise, inputCells: inputCellsPerBitwise},
+	EcOpName:       {cells: cellsPerEcOp, inputCells: inputCellsPerEcOp},
+	PoseidonName:   {cells: cellsPerPoseidon, inputCells: inputCellsPerPoseidon},
+	AddModName:     {cells: cellsPerMod, inputCells: inputCellsPerMod},
+	MulModName:     {cells: cellsPerMod, inputCells: inputCellsPerMod},
+}
+
+// CheckSegmentSecurity validates the memory layout of a builtin segment the same way
+// cairo-lang `run_security_checks` does. The instances span every known cell, the last
+// one possibly only partially used, and
+//   - every input cell of those instances is known, so the output segment, where every
+//     cell is an input, has no holes since it is public memory,
+//   - the known deduced cells equal what the builtin deduces from the inputs.
+func CheckSegmentSecurity(builtinRunner memory.BuiltinRunner, segment *memory.Segment) error {
+	layout, ok := instanceLayouts[builtinRunner.String()]
+	if !ok {
+		return fmt.Errorf("unknown builtin %s", builtinRunner)
+	}
+
+	// The used size can include padding cells added when finalizing the segment,
+	// so the instances are counted from the last known cell instead
+	knownSize := uint64(0)
+	for offset := segment.RealLen(); offset > 0; offset-- {
+		cell := segment.Peek(offset - 1)
+		if cell.Known() {
+			knownSize = offset
+			break
+		}
+	}
+	nInstances := (knownSize + layout.cells - 1) / layout.cells
+
+	var missing []uint64
+	for instance := uint64(0); instance < nInstances; instance++ {
+		for i := uint64(0); i < layout.inputCells; i++ {
+			offset := instance*layout.cells + i
+			input := segment.Peek(offset)
+			if !input.Known() {
+				missing = append(missing, offset)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		if builtinRunner.String() == OutputName {
+			return fmt.Errorf("hole in public memory at offset %d", missing[0])
+		}
+		if len(missing) > 20 {
+			missing = missing[:20]
+		}
+		return fmt.Errorf("missing memory cells for %s: %v", builtinRunner, missing)
+	}
+
+	for instance := uint64(0); instance < nInstances; instance++ {
+		if err := checkDeductions(builtinRunner, segment, layout, instance*layout.cells); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeduceSegment deduces every unknown output cell of the builtin instances whose
+// inputs are all known. Instances missing some of their inputs are left untouched
+func DeduceSegment(builtinRunner memory.BuiltinRunner, segment *memory.Segment) error {
+	layout, ok := instanceLayouts[builtinRunner.String()]
+	if !ok {
+		return fmt.Errorf("unknown builtin %s", builtinRunner)
+	}
+	if layout.inputCells == layout.cells {
+		return nil
+	}
+
+	usedSize := segment.Len()
+	for instanceOffset := uint64(0); instanceOffset < usedSize; instanceOffset += layout.cells {
+		complete := true
+		for i := uint64(0); i < layout.inputCells; i++ {
+			input := segment.Peek(instanceOffset + i)
+			if !input.Known() {
+				complete = false
+				break
+			}
+		}
+		if !complete {
+			continue
+		}
+
+		for i := layout.inputCells; i < layout.cells; i++ {
+			if _, err := segment.Read(instanceOffset + i); err != nil {
+				return fmt.Errorf("instance at offset %d: %w", instanceOffset, err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkDeductions deduces again the cells of the instance at `instanceOffset` on a
+// scratch segment, and compares them with the known ones
+func checkDeductions(
+	builtinRunner memory.BuiltinRunner, segment *memory.Segment, layout instanceLayout, instanceOffset uint64,
+) error {
+	if layout.inputCells == layout.cells {
+		return nil
+	}
+
+	scratch := memory.EmptySegmentWithLength(int(layout.cells)).WithBuiltinRunner(builtinRunner)
+	for i := uint64(0); i < layout.inputCells; i++ {
+		input := segment.Peek(instanceOffset + i)
+		if err := scratch.Write(i, &input); err != nil {
+			return fmt.Errorf("instance at offset %d: %w", instanceOffset, err)
+		}
+	}
+
+	for i := layout.inputCells; i < layout.cells; i++ {
+		actual := segment.Peek(instanceOffset + i)
+		if !actual.Known() {
+			continue
+		}
+		expected, err := scratch.Read(i)
+		if err != nil {
+			return fmt.Errorf("instance at offset %d: %w", instanceOffset, err)
+		}
+		if !actual.Equal(&expected) {
+			return fmt.Errorf(
+				"value at offset %d is %s but the builtin deduces %s",
+				instanceOffset+i, &actual, &expected,
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package builtins
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSegmentSecurity(t *testing.T) {
+	newBitwiseSegment := func(values ...int) *memory.Segment {
+		segment := memory.EmptySegment().WithBuiltinRunner(&Bitwise{})
+		for i, v := range values {
+			if v < 0 {
+				continue
+			}
+			mv := memory.MemoryValueFromInt(v)
+			require.NoError(t, segment.Write(uint64(i), &mv))
+		}
+		return segment
+	}
+
+	// 14 & 7, 14 ^ 7, 14 | 7, and an instance with no cells written
+	segment := newBitwiseSegment(14, 7, 6, 9, 15, -1, -1, -1, -1, -1)
+	segment.Finalize(10)
+	require.NoError(t, CheckSegmentSecurity(&Bitwise{}, segment))
+
+	// the last instance is only partially used, only x & y being read from it
+	segment = newBitwiseSegment(14, 7, 6, 9, 15, 3, 5, 1)
+	require.NoError(t, CheckSegmentSecurity(&Bitwise{}, segment))
+
+	// its deduced cells are still checked
+	segment = newBitwiseSegment(14, 7, 6, 9, 15, 3, 5, 2)
+	require.ErrorContains(t, CheckSegmentSecurity(&Bitwise{}, segment), "value at offset 7 is 2 but the builtin deduces 1")
+
+	segment = newBitwiseSegment(14)
+	segment.Finalize(5)
+	require.ErrorContains(t, CheckSegmentSecurity(&Bitwise{}, segment), "missing memory cells for bitwise: [1]")
+
+	// an instance with no cells written before a used one is missing its inputs
+	segment = newBitwiseSegment(-1, -1, -1, -1, -1, 14, 7, 6)
+	require.ErrorContains(t, CheckSegmentSecurity(&Bitwise{}, segment), "missing memory cells for bitwise: [0 1]")
+
+	segment = newBitwiseSegment(14, 7, 6, 8, 15)
+	require.ErrorContains(t, CheckSegmentSecurity(&Bitwise{}, segment), "value at offset 3 is 8 but the builtin deduces 9")
+
+	output := memory.EmptySegment().WithBuiltinRunner(&Output{})
+	mv := memory.MemoryValueFromInt(1)
+	require.NoError(t, output.Write(0, &mv))
+	require.NoError(t, output.Write(2, &mv))
+	require.ErrorContains(t, CheckSegmentSecurity(&Output{}, output), "hole in public memory at offset 1")
+}
@@ -2,6 +2,7 @@ package builtins
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/utils"
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
@@ -136,6 +137,55 @@ func (e *ECDSA) AddSignature(pubOffset uint64, r, s *fp.Element) error {
 	return nil
 }
 
+// ECDSAPrivateInput is the air private input entry of one ecdsa builtin instance
+type ECDSAPrivateInput struct {
+	// Index of the instance within the builtin segment
+	Index uint64
+	// Relocated address of the instance, i.e. of its public key cell
+	Address uint64
+	PubKey  fp.Element
+	Message fp.Element
+	R       fp.Element
+	S       fp.Element
+}
+
+// AirPrivateInput returns an entry for every signature added to the builtin, sorted
+// by instance index. `segmentOffset` is the relocated address of the builtin segment
+// base, signatures are tracked by their offset inside the segment so it is added to them
+func (e *ECDSA) AirPrivateInput(segment *memory.Segment, segmentOffset uint64) ([]ECDSAPrivateInput, error) {
+	pubOffsets := make([]uint64, 0, len(e.signatures))
+	for pubOffset := range e.signatures {
+		pubOffsets = append(pubOffsets, pubOffset)
+	}
+	sort.Slice(pubOffsets, func(i, j int) bool { return pubOffsets[i] < pubOffsets[j] })
+
+	privateInput := make([]ECDSAPrivateInput, 0, len(pubOffsets))
+	for _, pubOffset := range pubOffsets {
+		pub := segment.Peek(pubOffset)
+		pubKey, err := pub.FieldElement()
+		if err != nil {
+			return nil, fmt.Errorf("public key at offset %d: %w", pubOffset, err)
+		}
+		msg := segment.Peek(pubOffset + 1)
+		message, err := msg.FieldElement()
+		if err != nil {
+			return nil, fmt.Errorf("message at offset %d: %w", pubOffset+1, err)
+		}
+
+		sig := e.signatures[pubOffset]
+		entry := ECDSAPrivateInput{
+			Index:   pubOffset / cellsPerECDSA,
+			Address: segmentOffset + pubOffset,
+			PubKey:  *pubKey,
+			Message: *message,
+		}
+		entry.R.SetBytes(sig.R[:])
+		entry.S.SetBytes(sig.S[:])
+		privateInput = append(privateInput, entry)
+	}
+	return privateInput, nil
+}
+
 func (e *ECDSA) String() string {
 	return ECDSAName
 }
@@ -45,3 +45,56 @@ func TestECDSAInvalidSig(t *testing.T) {
 	require.ErrorContains(t, err, "signature is not valid")
 
 }
+
+func TestECDSAAirPrivateInput(t *testing.T) {
+	ecdsa := &ECDSA{}
+	segment := memory.EmptySegmentWithLength(4)
+	segment.WithBuiltinRunner(ecdsa)
+
+	type signature struct{ pubkey, msg, r, s string }
+	signatures := []signature{
+		{
+			pubkey: "1735102664668487605176656616876767369909409133946409161569774794110049207117",
+			msg:    "2718",
+			r:      "3086480810278599376317923499561306189851900463386393948998357832163236918254",
+			s:      "598673427589502599949712887611119751108407514580626464031881322743364689811",
+		},
+		{
+			pubkey: "1434136217923271508453866209352937660241067429834292808151286080516596591512",
+			msg:    "4242",
+			r:      "2926129818382058060292797586226983168500087817366526668609240955782226185378",
+			s:      "521003974718548443846529896507755021534886469023959948679548204247163493115",
+		},
+	}
+
+	// Signatures are added in reverse order, the private input is still sorted by index
+	for i := len(signatures) - 1; i >= 0; i-- {
+		sig := signatures[i]
+		pubOffset := uint64(i * cellsPerECDSA)
+		pubkey, _ := new(fp.Element).SetString(sig.pubkey)
+		msg, _ := new(fp.Element).SetString(sig.msg)
+		r, _ := new(fp.Element).SetString(sig.r)
+		s, _ := new(fp.Element).SetString(sig.s)
+
+		pubkeyValue := memory.MemoryValueFromFieldElement(pubkey)
+		msgValue := memory.MemoryValueFromFieldElement(msg)
+		require.NoError(t, ecdsa.AddSignature(pubOffset, r, s))
+		require.NoError(t, segment.Write(pubOffset, &pubkeyValue))
+		require.NoError(t, segment.Write(pubOffset+1, &msgValue))
+	}
+
+	const segmentOffset = 17
+	privateInput, err := ecdsa.AirPrivateInput(segment, segmentOffset)
+	require.NoError(t, err)
+	require.Len(t, privateInput, len(signatures))
+
+	for i, sig := range signatures {
+		entry := privateInput[i]
+		require.Equal(t, uint64(i), entry.Index)
+		require.Equal(t, uint64(segmentOffset+i*cellsPerECDSA), entry.Address)
+		require.Equal(t, sig.pubkey, entry.PubKey.String())
+		require.Equal(t, sig.msg, entry.Message.String())
+		require.Equal(t, sig.r, entry.R.String())
+		require.Equal(t, sig.s, entry.S.String())
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/utils"
 	mem "github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
 	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 	"github.com/holiman/uint256"
 )
@@ -15,13 +16,10 @@ const cellsPerEcOp = 7
 const inputCellsPerEcOp = 5
 const instancesPerComponentEcOp = 1
 
-var feltThree f.Element = f.Element(
-	[]uint64{
-		18446744073709551521,
-		18446744073709551615,
-		18446744073709551615,
-		576460752303421872,
-	})
+// In the python VM the scalar height is a parameter of the builtin but it is
+// always set at 256, therefore we treat it as a constant. It bounds the scalar
+// `m` to [0, 2**256)
+const ecOpScalarHeight = 256
 
 type EcOp struct {
 	ratio uint64
@@ -71,10 +69,6 @@ func (e *EcOp) InferValue(segment *mem.Segment, offset uint64) error {
 		inputsFelt[i] = felt
 	}
 
-	// Note: the python vm has an upper limit on the size of `m`(the fifth input)  but
-	// since it is always maxout at 2**252, I see no point on adding a check
-	// for it for now
-
 	// verify p and q are in the curve
 	p := point{*inputsFelt[0], *inputsFelt[1]}
 	q := point{*inputsFelt[2], *inputsFelt[3]}
@@ -86,7 +80,7 @@ func (e *EcOp) InferValue(segment *mem.Segment, offset uint64) error {
 	}
 
 	// calculate the elliptic curve operation
-	r, err := ecop(&p, &q, inputsFelt[4], &utils.Alpha)
+	r, err := ecop(&p, &q, inputsFelt[4])
 	if err != nil {
 		return err
 	}
@@ -137,95 +131,56 @@ func (p *point) onCurve(alpha, beta *f.Element) bool {
 }
 
 // returns the result of the ecop operation on points `P` and `Q` with scalar
-// `m` and param `alpha`. The resulting point `R` is equal to  P + m * Q
-func ecop(p *point, q *point, m, alpha *f.Element) (point, error) {
-	partialSum := *p
-	doublePoint := *q
+// `m`. The resulting point `R` is equal to  P + m * Q
+//
+// The double-and-add runs in jacobian coordinates to avoid a field inversion per
+// step. It still visits every partial sum, so that the same points as the python
+// VM are rejected, and only converts back to affine coordinates at the end.
+// The curve `alpha` param is the stark curve one, which gnark's formulas rely on
+func ecop(p *point, q *point, m *f.Element) (point, error) {
+	partialSum := starkcurve.G1Jac{}
+	partialSum.FromAffine(&starkcurve.G1Affine{X: p.X, Y: p.Y})
+	doublePoint := starkcurve.G1Jac{}
+	doublePoint.FromAffine(&starkcurve.G1Affine{X: q.X, Y: q.Y})
 
 	mBytes := m.Bytes()
 	scalar := uint256.Int{}
 	scalar.SetBytes32(mBytes[:])
 
-	// Note: In the python VM the height is a parameter but it is always set at 256
-	// therefore we treat it as a constant
-	const height = 256
-	// todo(rodro): iteration could be cut short on the biggest bit with a one of the `scalar`
-	for i := 0; i < height && !scalar.IsZero(); i++ {
+	for i := 0; i < ecOpScalarHeight && !scalar.IsZero(); i++ {
 		// we check that both points are always different between each others
-		// `ecadd` assume `x` ordinates are always different
-		// `ecdouble` assumes `y` coordinates are always different
-		if doublePoint.X.Equal(&partialSum.X) || doublePoint.Y.Equal(&utils.FeltZero) {
+		// jacobian addition would silently double equal points
+		if sameX(&doublePoint, &partialSum) || doublePoint.Y.IsZero() {
 			return point{}, fmt.Errorf(
 				"EcOp requires from P(%s, %s) and Q(%s, %s) that P.X != Q.X and Q.Y != 0 ",
 				&p.X, &p.Y, &q.X, &q.Y,
 			)
 		}
-		and := uint256.Int{}
-		and.And(&scalar, &utils.Uint256One)
-		if !and.IsZero() {
-			partialSum = ecadd(&partialSum, &doublePoint)
+		if scalar[0]&1 == 1 {
+			partialSum.AddAssign(&doublePoint)
 		}
 
-		// todo(rodro): This loop can be optimized, potentially innecesary shift operations
-		doublePoint = ecdouble(&doublePoint, alpha)
+		doublePoint.DoubleAssign()
 		scalar.Rsh(&scalar, 1)
 	}
 
-	return partialSum, nil
-}
+	// felts always fit in the scalar limit, but keep the check to match the python VM
+	if !scalar.IsZero() {
+		return point{}, fmt.Errorf("EcOp scalar m (%s) is out of the range [0, 2**%d)", m, ecOpScalarHeight)
+	}
 
-// performs elliptic curve addition over two points. Assumes `x` ordinates are
-// always different
-func ecadd(p *point, q *point) point {
-	// get the slope between the two points
-	slope := f.Element{}
-	slope.Sub(&p.Y, &q.Y)
-	denom := f.Element{}
-	denom.Sub(&p.X, &q.X)
-	slope.Div(&slope, &denom)
-
-	// get the x coordinate: x = slope^2 - p.X - q.X
-	x := f.Element{}
-	x.Square(&slope)
-	x.Sub(&x, &p.X)
-	x.Sub(&x, &q.X)
-
-	// get the y coordinate: y = slope * (p.X - x) - p.Y
-	y := f.Element{}
-	y.Sub(&p.X, &x)
-	y.Mul(&y, &slope)
-	y.Sub(&y, &p.Y)
-
-	return point{x, y}
+	r := starkcurve.G1Affine{}
+	r.FromJacobian(&partialSum)
+	return point{r.X, r.Y}, nil
 }
 
-// performs elliptic curve doubling over a point. Assumes `y` coordinate
-// is different than 0
-func ecdouble(p *point, alpha *f.Element) point {
-	// get the double slope
-	doubleSlope := f.Element{}
-	doubleSlope.Square(&p.X)
-	doubleSlope.Mul(
-		&doubleSlope,
-		&feltThree,
-	)
-	doubleSlope.Add(&doubleSlope, alpha)
-	denom := f.Element{}
-	denom.Double(&p.Y)
-	doubleSlope.Div(&doubleSlope, &denom)
-
-	// get the x coordinate: x = slope^2 - 2 * p.X
-	x := f.Element{}
-	x.Square(&doubleSlope)
-	doublePx := f.Element{}
-	doublePx.Double(&p.X)
-	x.Sub(&x, &doublePx)
-
-	// get the y coordinates: y =  slope * (p.X - x) - p.Y
-	y := f.Element{}
-	y.Sub(&p.X, &x)
-	y.Mul(&y, &doubleSlope)
-	y.Sub(&y, &p.Y)
-
-	return point{x, y}
+// returns true if the two jacobian points share the same affine `x` ordinate,
+// i.e. X1 * Z2^2 == X2 * Z1^2
+func sameX(a, b *starkcurve.G1Jac) bool {
+	var aZZ, bZZ, lhs, rhs f.Element
+	aZZ.Square(&a.Z)
+	bZZ.Square(&b.Z)
+	lhs.Mul(&a.X, &bZZ)
+	rhs.Mul(&b.X, &aZZ)
+	return lhs.Equal(&rhs)
 }
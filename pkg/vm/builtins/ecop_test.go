@@ -1,6 +1,7 @@
 package builtins
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/utils"
@@ -62,6 +63,235 @@ func TestEcOp(t *testing.T) {
 	require.Equal(t, r.Y, *ry)
 }
 
+func TestEcOpKnownResult(t *testing.T) {
+	segment := ecOpSegment(t,
+		"0x49EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804",
+		"0x3CA0CFE4B3BC6DDF346D49D06EA0ED34E621062C0E056C1D0405D266E10268A",
+		"0x1EF15C18599971B7BECED415A40F0C7DEACFD9B0D1819E03D723D8BC943CFCA",
+		"0x5668060AA49730B7BE4801DF46EC62DE53ECD11ABE43A32873000C36E8DC1F",
+		"0x1234567890abcdef1234567890abcdef",
+	)
+
+	rx, err := segment.Read(5)
+	require.NoError(t, err)
+	ry, err := segment.Read(6)
+	require.NoError(t, err)
+
+	expectedRx, _ := new(fp.Element).SetString("0x7c9395e022ea16f716daf5cacb24adc10b60ead553c1f4ea966490934396882")
+	expectedRy, _ := new(fp.Element).SetString("0x9703cd450f1382bb67f1ed1b6b573316f7a5f7f079d6bdd3504ee8b6cfc341")
+	require.Equal(t, memory.MemoryValueFromFieldElement(expectedRx), rx)
+	require.Equal(t, memory.MemoryValueFromFieldElement(expectedRy), ry)
+}
+
+func TestEcOpPointNotOnCurve(t *testing.T) {
+	// P.y is off by one
+	segment := ecOpSegment(t,
+		"0x49EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804",
+		"0x3CA0CFE4B3BC6DDF346D49D06EA0ED34E621062C0E056C1D0405D266E10268B",
+		"0x1EF15C18599971B7BECED415A40F0C7DEACFD9B0D1819E03D723D8BC943CFCA",
+		"0x5668060AA49730B7BE4801DF46EC62DE53ECD11ABE43A32873000C36E8DC1F",
+		"0x3",
+	)
+	_, err := segment.Read(5)
+	require.ErrorContains(t, err, "is not on the curve")
+	require.ErrorContains(t, err, "point P")
+
+	// Q.x is off by one
+	segment = ecOpSegment(t,
+		"0x49EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804",
+		"0x3CA0CFE4B3BC6DDF346D49D06EA0ED34E621062C0E056C1D0405D266E10268A",
+		"0x1EF15C18599971B7BECED415A40F0C7DEACFD9B0D1819E03D723D8BC943CFCB",
+		"0x5668060AA49730B7BE4801DF46EC62DE53ECD11ABE43A32873000C36E8DC1F",
+		"0x3",
+	)
+	_, err = segment.Read(6)
+	require.ErrorContains(t, err, "is not on the curve")
+	require.ErrorContains(t, err, "point Q")
+}
+
+// creates an ec_op segment with its 5 input cells written
+func ecOpSegment(t *testing.T, inputs ...string) *memory.Segment {
+	segment := memory.EmptySegmentWithLength(cellsPerEcOp)
+	segment.WithBuiltinRunner(&EcOp{})
+	for i, input := range inputs {
+		felt, err := new(fp.Element).SetString(input)
+		require.NoError(t, err)
+		value := memory.MemoryValueFromFieldElement(felt)
+		require.NoError(t, segment.Write(uint64(i), &value))
+	}
+	return segment
+}
+
+func TestEcOpMatchesAffine(t *testing.T) {
+	p := point{}
+	p.X.SetString("0x49EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804")
+	p.Y.SetString("0x3CA0CFE4B3BC6DDF346D49D06EA0ED34E621062C0E056C1D0405D266E10268A")
+	q := point{}
+	q.X.SetString("0x1EF15C18599971B7BECED415A40F0C7DEACFD9B0D1819E03D723D8BC943CFCA")
+	q.Y.SetString("0x5668060AA49730B7BE4801DF46EC62DE53ECD11ABE43A32873000C36E8DC1F")
+
+	scalars := []string{
+		"0x0",
+		"0x1",
+		"0x2",
+		"0x1234567890abcdef1234567890abcdef",
+		"0x7ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"0x800000000000011000000000000000000000000000000000000000000000000",
+	}
+	for _, scalar := range scalars {
+		m, err := new(fp.Element).SetString(scalar)
+		require.NoError(t, err)
+
+		expected, err := ecopAffine(&p, &q, m, &utils.Alpha)
+		require.NoError(t, err)
+		actual, err := ecop(&p, &q, m)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual, "m = %s", scalar)
+	}
+
+	// P == Q is rejected by both implementations
+	m := new(fp.Element).SetUint64(3)
+	_, expectedErr := ecopAffine(&q, &q, m, &utils.Alpha)
+	_, err := ecop(&q, &q, m)
+	require.Error(t, expectedErr)
+	require.Equal(t, expectedErr, err)
+}
+
+func BenchmarkEcOp(b *testing.B) {
+	p, q, m := ecOpBenchmarkInputs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ecop(&p, &q, &m); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEcOpAffine(b *testing.B) {
+	p, q, m := ecOpBenchmarkInputs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ecopAffine(&p, &q, &m, &utils.Alpha); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func ecOpBenchmarkInputs() (point, point, fp.Element) {
+	p := point{}
+	p.X.SetString("0x49EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804")
+	p.Y.SetString("0x3CA0CFE4B3BC6DDF346D49D06EA0ED34E621062C0E056C1D0405D266E10268A")
+	q := point{}
+	q.X.SetString("0x1EF15C18599971B7BECED415A40F0C7DEACFD9B0D1819E03D723D8BC943CFCA")
+	q.Y.SetString("0x5668060AA49730B7BE4801DF46EC62DE53ECD11ABE43A32873000C36E8DC1F")
+	m := fp.Element{}
+	m.SetString("0x7ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	return p, q, m
+}
+
+var feltThree fp.Element = fp.Element(
+	[]uint64{
+		18446744073709551521,
+		18446744073709551615,
+		18446744073709551615,
+		576460752303421872,
+	})
+
+// naive affine implementation of `ecop`, used as a reference for the
+// jacobian one. The resulting point `R` is equal to  P + m * Q
+func ecopAffine(p *point, q *point, m, alpha *fp.Element) (point, error) {
+	partialSum := *p
+	doublePoint := *q
+
+	mBytes := m.Bytes()
+	scalar := uint256.Int{}
+	scalar.SetBytes32(mBytes[:])
+
+	for i := 0; i < ecOpScalarHeight && !scalar.IsZero(); i++ {
+		// we check that both points are always different between each others
+		// `ecadd` assume `x` ordinates are always different
+		// `ecdouble` assumes `y` coordinates are always different
+		if doublePoint.X.Equal(&partialSum.X) || doublePoint.Y.Equal(&utils.FeltZero) {
+			return point{}, fmt.Errorf(
+				"EcOp requires from P(%s, %s) and Q(%s, %s) that P.X != Q.X and Q.Y != 0 ",
+				&p.X, &p.Y, &q.X, &q.Y,
+			)
+		}
+		and := uint256.Int{}
+		and.And(&scalar, &utils.Uint256One)
+		if !and.IsZero() {
+			partialSum = ecadd(&partialSum, &doublePoint)
+		}
+
+		// todo(rodro): This loop can be optimized, potentially innecesary shift operations
+		doublePoint = ecdouble(&doublePoint, alpha)
+		scalar.Rsh(&scalar, 1)
+	}
+
+	// felts always fit in the scalar limit, but keep the check to match the python VM
+	if !scalar.IsZero() {
+		return point{}, fmt.Errorf("EcOp scalar m (%s) is out of the range [0, 2**%d)", m, ecOpScalarHeight)
+	}
+
+	return partialSum, nil
+}
+
+// performs elliptic curve addition over two points. Assumes `x` ordinates are
+// always different
+func ecadd(p *point, q *point) point {
+	// get the slope between the two points
+	slope := fp.Element{}
+	slope.Sub(&p.Y, &q.Y)
+	denom := fp.Element{}
+	denom.Sub(&p.X, &q.X)
+	slope.Div(&slope, &denom)
+
+	// get the x coordinate: x = slope^2 - p.X - q.X
+	x := fp.Element{}
+	x.Square(&slope)
+	x.Sub(&x, &p.X)
+	x.Sub(&x, &q.X)
+
+	// get the y coordinate: y = slope * (p.X - x) - p.Y
+	y := fp.Element{}
+	y.Sub(&p.X, &x)
+	y.Mul(&y, &slope)
+	y.Sub(&y, &p.Y)
+
+	return point{x, y}
+}
+
+// performs elliptic curve doubling over a point. Assumes `y` coordinate
+// is different than 0
+func ecdouble(p *point, alpha *fp.Element) point {
+	// get the double slope
+	doubleSlope := fp.Element{}
+	doubleSlope.Square(&p.X)
+	doubleSlope.Mul(
+		&doubleSlope,
+		&feltThree,
+	)
+	doubleSlope.Add(&doubleSlope, alpha)
+	denom := fp.Element{}
+	denom.Double(&p.Y)
+	doubleSlope.Div(&doubleSlope, &denom)
+
+	// get the x coordinate: x = slope^2 - 2 * p.X
+	x := fp.Element{}
+	x.Square(&doubleSlope)
+	doublePx := fp.Element{}
+	doublePx.Double(&p.X)
+	x.Sub(&x, &doublePx)
+
+	// get the y coordinates: y =  slope * (p.X - x) - p.Y
+	y := fp.Element{}
+	y.Sub(&p.X, &x)
+	y.Mul(&y, &doubleSlope)
+	y.Sub(&y, &p.Y)
+
+	return point{x, y}
+}
+
 // performs elliptic curve multiplication on point `p` with scalar `m` and param `alpha`.
 // `m` value gets modified in place
 func ecmult(p *point, m *uint256.Int, alpha *fp.Element) point {
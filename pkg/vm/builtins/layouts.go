@@ -36,6 +36,15 @@ func getPlainLayout() Layout {
 	return Layout{Name: "plain", RcUnits: 16, Builtins: []LayoutBuiltin{}}
 }
 
+func getRecursiveLayout() Layout {
+	return Layout{Name: "recursive", RcUnits: 4, Builtins: []LayoutBuiltin{
+		{Runner: &Output{}, Builtin: starknet.Output},
+		{Runner: &Pedersen{ratio: 128}, Builtin: starknet.Pedersen},
+		{Runner: &RangeCheck{ratio: 8, RangeCheckNParts: 8, InnerRCBound: 2 << 16}, Builtin: starknet.RangeCheck},
+		{Runner: &Bitwise{ratio: 8}, Builtin: starknet.Bitwise},
+	}}
+}
+
 func getStarknetWithKeccakLayout() Layout {
 	return Layout{Name: "starknet_with_keccak", RcUnits: 4, Builtins: []LayoutBuiltin{
 		{Runner: &Output{}, Builtin: starknet.Output},
@@ -49,17 +58,56 @@ func getStarknetWithKeccakLayout() Layout {
 	}}
 }
 
+// The range_check96, add_mod and mul_mod builtins of the all_cairo layout are not
+// supported yet, so the layout only holds the builtins preceding them
+func getAllCairoLayout() Layout {
+	return Layout{Name: "all_cairo", RcUnits: 4, Builtins: []LayoutBuiltin{
+		{Runner: &Output{}, Builtin: starknet.Output},
+		{Runner: &Pedersen{ratio: 256}, Builtin: starknet.Pedersen},
+		{Runner: &RangeCheck{ratio: 8, RangeCheckNParts: 8, InnerRCBound: 2 << 16}, Builtin: starknet.RangeCheck},
+		{Runner: &ECDSA{ratio: 2048}, Builtin: starknet.ECDSA},
+		{Runner: &Bitwise{ratio: 16}, Builtin: starknet.Bitwise},
+		{Runner: &EcOp{ratio: 1024}, Builtin: starknet.ECOP},
+		{Runner: &Keccak{ratio: 2048}, Builtin: starknet.Keccak},
+		{Runner: &Poseidon{ratio: 256}, Builtin: starknet.Poseidon},
+	}}
+}
+
+// layouts maps each supported layout name to the function building it. Builtin runners
+// keep state during a run, so every runner gets its own copy of the layout
+var layouts = map[string]func() Layout{
+	"plain":                getPlainLayout,
+	"small":                getSmallLayout,
+	"recursive":            getRecursiveLayout,
+	"starknet_with_keccak": getStarknetWithKeccakLayout,
+	"all_cairo":            getAllCairoLayout,
+}
+
 func GetLayout(layout string) (Layout, error) {
-	switch layout {
-	case "small":
-		return getSmallLayout(), nil
-	case "plain":
-		return getPlainLayout(), nil
-	case "starknet_with_keccak":
-		return getStarknetWithKeccakLayout(), nil
-	case "":
+	if layout == "" {
 		return getPlainLayout(), nil
-	default:
+	}
+	getLayout, ok := layouts[layout]
+	if !ok {
 		return Layout{}, fmt.Errorf("Layout %s not found", layout)
 	}
+	return getLayout(), nil
+}
+
+// CheckBuiltins makes sure every builtin used by a program is part of the layout
+func (layout *Layout) CheckBuiltins(programBuiltins []starknet.Builtin) error {
+	builtinsSet := make(map[starknet.Builtin]bool)
+	for _, bRunner := range layout.Builtins {
+		builtinsSet[bRunner.Builtin] = true
+	}
+	for _, programBuiltin := range programBuiltins {
+		if _, found := builtinsSet[programBuiltin]; !found {
+			builtinName, err := programBuiltin.MarshalJSON()
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("builtin %s not found in the layout: %s", builtinName, layout.Name)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,80 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+const AddModName = "add_mod"
+const MulModName = "mul_mod"
+
+// The modulus `p` is split in 4 limbs of 96 bits each
+const wordBitLenMod = 96
+const nWordsMod = 4
+
+// An instance is made of the limbs of `p`, followed by `values_ptr`, `offsets_ptr` and `n`
+const cellsPerMod = nWordsMod + 3
+const inputCellsPerMod = cellsPerMod
+const instancesPerComponentMod = 1
+
+const valuesPtrOffsetMod = nWordsMod
+const offsetsPtrOffsetMod = nWordsMod + 1
+const nOffsetMod = nWordsMod + 2
+
+// ModBuiltin is the groundwork for the add_mod and mul_mod builtins. It only validates
+// the layout of its instances, the arithmetic circuits they describe aren't run yet
+type ModBuiltin struct {
+	ratio uint64
+	name  string
+}
+
+func (m *ModBuiltin) CheckWrite(segment *memory.Segment, offset uint64, value *memory.MemoryValue) error {
+	switch offset % cellsPerMod {
+	case valuesPtrOffsetMod:
+		if !value.IsAddress() {
+			return fmt.Errorf("values_ptr at offset %d: expected an address but got a felt: %s", offset, value)
+		}
+	case offsetsPtrOffsetMod:
+		if !value.IsAddress() {
+			return fmt.Errorf("offsets_ptr at offset %d: expected an address but got a felt: %s", offset, value)
+		}
+	case nOffsetMod:
+		if !value.IsFelt() {
+			return fmt.Errorf("n at offset %d: expected a felt but got an address: %s", offset, value)
+		}
+	default:
+		limb, err := value.FieldElement()
+		if err != nil {
+			return fmt.Errorf("p limb at offset %d: %w", offset, err)
+		}
+		if limb.Cmp(&modWordBound) >= 0 {
+			return fmt.Errorf("p limb at offset %d: %s is out of range [0, 2**%d)", offset, limb, wordBitLenMod)
+		}
+	}
+	return nil
+}
+
+func (m *ModBuiltin) InferValue(segment *memory.Segment, offset uint64) error {
+	return errors.New("cannot infer value: mod builtin cells are all inputs")
+}
+
+func (m *ModBuiltin) String() string {
+	return m.name
+}
+
+func (m *ModBuiltin) GetAllocatedSize(segmentUsedSize uint64, vmCurrentStep uint64) (uint64, error) {
+	return getBuiltinAllocatedSize(segmentUsedSize, vmCurrentStep, m.ratio, inputCellsPerMod, instancesPerComponentMod, cellsPerMod)
+}
+
+// 2**96, the exclusive upper bound of a limb of `p`
+var modWordBound = func() fp.Element {
+	var bound fp.Element
+	bound.SetOne()
+	for i := 0; i < wordBitLenMod; i++ {
+		bound.Double(&bound)
+	}
+	return bound
+}()
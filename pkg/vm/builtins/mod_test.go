@@ -0,0 +1,50 @@
+package builtins
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModBuiltinLayout(t *testing.T) {
+	mod := &ModBuiltin{name: AddModName}
+	segment := memory.EmptySegmentWithLength(cellsPerMod).WithBuiltinRunner(mod)
+
+	for i := uint64(0); i < nWordsMod; i++ {
+		limb := memory.MemoryValueFromUint(uint64(7 + i))
+		require.NoError(t, segment.Write(i, &limb))
+	}
+	valuesPtr := memory.MemoryValueFromSegmentAndOffset(2, 0)
+	require.NoError(t, segment.Write(valuesPtrOffsetMod, &valuesPtr))
+	offsetsPtr := memory.MemoryValueFromSegmentAndOffset(3, 0)
+	require.NoError(t, segment.Write(offsetsPtrOffsetMod, &offsetsPtr))
+	n := memory.MemoryValueFromUint(uint64(1))
+	require.NoError(t, segment.Write(nOffsetMod, &n))
+
+	require.NoError(t, CheckSegmentSecurity(mod, segment))
+	assert.Equal(t, "add_mod", mod.String())
+}
+
+func TestModBuiltinInvalidLayout(t *testing.T) {
+	mod := &ModBuiltin{name: MulModName}
+	segment := memory.EmptySegmentWithLength(cellsPerMod).WithBuiltinRunner(mod)
+
+	var tooLarge fp.Element
+	tooLarge.Add(&modWordBound, new(fp.Element).SetOne())
+	limb := memory.MemoryValueFromFieldElement(&tooLarge)
+	require.ErrorContains(t, segment.Write(1, &limb), "p limb at offset 1")
+
+	address := memory.MemoryValueFromSegmentAndOffset(2, 0)
+	require.ErrorContains(t, segment.Write(0, &address), "p limb at offset 0")
+	require.ErrorContains(t, segment.Write(nOffsetMod, &address), "n at offset 6: expected a felt")
+
+	felt := memory.MemoryValueFromUint(uint64(1))
+	require.ErrorContains(t, segment.Write(valuesPtrOffsetMod, &felt), "values_ptr at offset 4: expected an address")
+	require.ErrorContains(t, segment.Write(cellsPerMod+offsetsPtrOffsetMod, &felt), "offsets_ptr at offset 12: expected an address")
+
+	_, err := segment.Read(2)
+	require.ErrorContains(t, err, "cannot infer value")
+}
@@ -4,8 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/NethermindEth/cairo-vm-go/pkg/crypto"
 	mem "github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
-	pedersenhash "github.com/consensys/gnark-crypto/ecc/stark-curve/pedersen-hash"
 )
 
 const PedersenName = "pedersen"
@@ -51,7 +51,7 @@ func (p *Pedersen) InferValue(segment *mem.Segment, offset uint64) error {
 		return err
 	}
 
-	hash := pedersenhash.Pedersen(xFelt, yFelt)
+	hash := crypto.Pedersen(*xFelt, *yFelt)
 	hashValue := mem.MemoryValueFromFieldElement(&hash)
 	return segment.Write(xOffset+2, &hashValue)
 }
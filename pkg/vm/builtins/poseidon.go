@@ -3,6 +3,7 @@ package builtins
 import (
 	"errors"
 
+	"github.com/NethermindEth/cairo-vm-go/pkg/crypto"
 	mem "github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 )
@@ -40,7 +41,7 @@ func (p *Poseidon) InferValue(segment *mem.Segment, offset uint64) error {
 	}
 
 	// poseidon hash calculation
-	hash := PoseidonPerm(poseidonInputValues[0], poseidonInputValues[1], poseidonInputValues[2])
+	hash := crypto.PoseidonPerm(poseidonInputValues[0], poseidonInputValues[1], poseidonInputValues[2])
 	for i := 0; i < 3; i++ {
 		hashValue := mem.MemoryValueFromFieldElement(&hash[i])
 		err := segment.Write(baseOffset+uint64(i+3), &hashValue)
@@ -141,7 +141,7 @@ func (segment *Segment) IncreaseSegmentSize(newSize uint64) {
 	}
 
 	var newSegmentData []MemoryValue
-	if cap(segmentData) > int(newSize) {
+	if cap(segmentData) >= int(newSize) {
 		newSegmentData = segmentData[:cap(segmentData)]
 	} else {
 		newSegmentData = make([]MemoryValue, utils.Max(newSize, uint64(len(segmentData)*2)))
@@ -191,6 +191,12 @@ func (segment *Segment) String() string {
 // Represents the whole VM memory divided into segments
 type Memory struct {
 	Segments []*Segment
+	// MaxSegmentSize limits the number of cells a segment can grow to when
+	// writing. Zero means there is no limit
+	MaxSegmentSize uint64
+	// offsets of the cells read, peeked or written by segment index. It stays nil,
+	// and nothing is recorded, until `TrackAccesses` is called
+	accessedAddresses map[uint64]map[uint64]struct{}
 }
 
 // todo(rodro): can the amount of segments be known before hand?
@@ -243,9 +249,16 @@ func (memory *Memory) Write(segmentIndex uint64, offset uint64, value *MemoryVal
 	if segmentIndex >= uint64(len(memory.Segments)) {
 		return fmt.Errorf("segment %d: unallocated", segmentIndex)
 	}
+	if memory.MaxSegmentSize != 0 && offset >= memory.MaxSegmentSize {
+		return fmt.Errorf(
+			"segment %d, offset %d: exceeds the maximum segment size of %d",
+			segmentIndex, offset, memory.MaxSegmentSize,
+		)
+	}
 	if err := memory.Segments[segmentIndex].Write(offset, value); err != nil {
 		return fmt.Errorf("segment %d, offset %d: %w", segmentIndex, offset, err)
 	}
+	memory.recordAccess(segmentIndex, offset)
 	return nil
 }
 
@@ -265,6 +278,7 @@ func (memory *Memory) Read(segmentIndex uint64, offset uint64) (MemoryValue, err
 	if err != nil {
 		return MemoryValue{}, fmt.Errorf("segment %d, offset %d: %w", segmentIndex, offset, err)
 	}
+	memory.recordAccess(segmentIndex, offset)
 	return mv, nil
 }
 
@@ -316,7 +330,11 @@ func (memory *Memory) Peek(segmentIndex uint64, offset uint64) (MemoryValue, err
 	if segmentIndex >= uint64(len(memory.Segments)) {
 		return MemoryValue{}, fmt.Errorf("segment %d: unallocated", segmentIndex)
 	}
-	return memory.Segments[segmentIndex].Peek(offset), nil
+	mv := memory.Segments[segmentIndex].Peek(offset)
+	if mv.Known() {
+		memory.recordAccess(segmentIndex, offset)
+	}
+	return mv, nil
 }
 
 // Given an address returns the memory value at that position, without
@@ -325,6 +343,44 @@ func (memory *Memory) PeekFromAddress(address *MemoryAddress) (MemoryValue, erro
 	return memory.Peek(address.SegmentIndex, address.Offset)
 }
 
+// TrackAccesses starts recording the addresses of the cells that are read, written or
+// peeked while known, so that `AccessedAddresses` can list them
+func (memory *Memory) TrackAccesses() {
+	if memory.accessedAddresses == nil {
+		memory.accessedAddresses = make(map[uint64]map[uint64]struct{})
+	}
+}
+
+// AccessedAddresses gives, segment by segment, the offsets of the cells accessed since
+// `TrackAccesses` was called. It is nil if the accesses aren't tracked
+func (memory *Memory) AccessedAddresses() map[uint64]map[uint64]struct{} {
+	return memory.accessedAddresses
+}
+
+func (memory *Memory) recordAccess(segmentIndex uint64, offset uint64) {
+	if memory.accessedAddresses == nil {
+		return
+	}
+	offsets, ok := memory.accessedAddresses[segmentIndex]
+	if !ok {
+		offsets = make(map[uint64]struct{})
+		memory.accessedAddresses[segmentIndex] = offsets
+	}
+	offsets[offset] = struct{}{}
+}
+
+// Given an address returns the raw value stored at that position and true if it is
+// known. Unlike `ReadFromAddress` it never runs builtin inference nor grows the segment,
+// so it is safe to use for read-only inspection. Unallocated segments and unknown
+// cells both return false
+func (memory *Memory) PeekKnown(address *MemoryAddress) (MemoryValue, bool) {
+	if address.SegmentIndex >= uint64(len(memory.Segments)) {
+		return UnknownValue, false
+	}
+	mv := memory.Segments[address.SegmentIndex].Peek(address.Offset)
+	return mv, mv.Known()
+}
+
 // Given a segment index and offset returns true if the value at that address
 // is known
 func (memory *Memory) KnownValue(segment uint64, offset uint64) bool {
@@ -358,6 +414,81 @@ func (memory *Memory) RelocationOffsets() ([]uint64, uint64) {
 	return segmentsOffsets, maxMemoryUsed
 }
 
+// It returns the effective size of every segment, indexed by the segment index
+func (memory *Memory) SegmentSizes() map[uint64]uint64 {
+	sizes := make(map[uint64]uint64, len(memory.Segments))
+	for i, segment := range memory.Segments {
+		sizes[uint64(i)] = segment.Len()
+	}
+	return sizes
+}
+
+// It returns the number of unknown cells of every segment, indexed by the segment
+// index. Only the cells below the effective size of a segment are counted
+func (memory *Memory) CountHoles() map[uint64]uint64 {
+	holes := make(map[uint64]uint64, len(memory.Segments))
+	for i, segment := range memory.Segments {
+		count := uint64(0)
+		for offset := uint64(0); offset < segment.Len(); offset++ {
+			if mv := segment.Peek(offset); !mv.Known() {
+				count++
+			}
+		}
+		holes[uint64(i)] = count
+	}
+	return holes
+}
+
+// It writes the given value in every unknown cell below the effective size of
+// each segment, so the memory has no holes. Builtin segments are left untouched,
+// their cells are only ever written by the program or deduced by their builtin
+func (memory *Memory) FillHoles(value MemoryValue) error {
+	for i, segment := range memory.Segments {
+		if _, ok := segment.BuiltinRunner.(*NoBuiltin); !ok {
+			continue
+		}
+		for offset := uint64(0); offset < segment.Len(); offset++ {
+			if mv := segment.Peek(offset); mv.Known() {
+				continue
+			}
+			if err := segment.Write(offset, &value); err != nil {
+				return fmt.Errorf("segment %d offset %d: %w", i, offset, err)
+			}
+		}
+	}
+	return nil
+}
+
+// It reports every relocatable value pointing to a segment that was never
+// allocated, or past the effective size of its segment. A pointer right after
+// the last cell of a segment, such as the end of an array, is valid
+func (memory *Memory) ValidateRelocatables() []error {
+	var errs []error
+	for i, segment := range memory.Segments {
+		for offset := range segment.Data {
+			mv := &segment.Data[offset]
+			if !mv.Known() || !mv.IsAddress() {
+				continue
+			}
+			address := mv.addrUnsafe()
+			if address.SegmentIndex >= uint64(len(memory.Segments)) {
+				errs = append(errs, fmt.Errorf(
+					"segment %d offset %d: relocatable %s points to an unallocated segment",
+					i, offset, address,
+				))
+				continue
+			}
+			if size := memory.Segments[address.SegmentIndex].Len(); address.Offset > size {
+				errs = append(errs, fmt.Errorf(
+					"segment %d offset %d: relocatable %s is out of the bounds of its segment of size %d",
+					i, offset, address, size,
+				))
+			}
+		}
+	}
+	return errs
+}
+
 // It finds a segment with a given builtin name, it returns the segment and true if found
 func (memory *Memory) FindSegmentWithBuiltin(builtinName string) (*Segment, bool) {
 	for i := range memory.Segments {
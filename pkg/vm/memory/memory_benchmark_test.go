@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"testing"
+)
+
+func BenchmarkMemoryWrite(b *testing.B) {
+	const cells = 1 << 21
+	value := MemoryValueFromUint(uint64(7))
+
+	b.Run("sequential", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			memory := InitializeEmptyMemory()
+			memory.AllocateEmptySegment()
+			for offset := uint64(0); offset < cells; offset++ {
+				if err := memory.Write(0, offset, &value); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("sparse", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			memory := InitializeEmptyMemory()
+			memory.AllocateEmptySegment()
+			for offset := uint64(0); offset < cells; offset += 16 {
+				if err := memory.Write(0, offset, &value); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("many segments", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			memory := InitializeEmptyMemory()
+			for segment := uint64(0); segment < cells/64; segment++ {
+				memory.AllocateEmptySegment()
+				for offset := uint64(0); offset < 64; offset++ {
+					if err := memory.Write(segment, offset, &value); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkMemoryRead(b *testing.B) {
+	const cells = 1 << 21
+	value := MemoryValueFromUint(uint64(7))
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	for offset := uint64(0); offset < cells; offset++ {
+		if err := memory.Write(0, offset, &value); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for offset := uint64(0); offset < cells; offset++ {
+			if _, err := memory.Read(0, offset); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
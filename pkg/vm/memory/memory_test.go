@@ -104,6 +104,44 @@ func TestIncreaseSegmentSizeDouble(t *testing.T) {
 	noErrorAndEqualSegmentRead(t, &segment, 0, MemoryValueFromInt(1))
 	noErrorAndEqualSegmentRead(t, &segment, 1, MemoryValueFromInt(2))
 }
+func TestIncreaseSegmentSizeWithinCapacity(t *testing.T) {
+	segment := EmptySegmentWithCapacity(4)
+	data := segment.Data[:cap(segment.Data)]
+
+	// growing up to the capacity reuses the allocated cells
+	segment.IncreaseSegmentSize(4)
+	assert.Equal(t, 4, len(segment.Data))
+	assert.Same(t, &data[0], &segment.Data[0])
+}
+
+func TestSegmentSparseWrites(t *testing.T) {
+	segment := EmptySegment()
+	offsets := []uint64{0, 7, 150, 1000}
+	for _, offset := range offsets {
+		require.NoError(t, segment.Write(offset, memoryValuePointerFromInt(int(offset)+1)))
+	}
+	assert.Equal(t, uint64(1001), segment.Len())
+
+	for _, offset := range offsets {
+		noErrorAndEqualSegmentRead(t, segment, offset, MemoryValueFromInt(int(offset)+1))
+	}
+
+	// the cells between the writes stay unknown
+	assert.Equal(t, UnknownValue, segment.Peek(8))
+	assert.Equal(t, UnknownValue, segment.Peek(999))
+	_, err := segment.Read(8)
+	assert.ErrorContains(t, err, "reading unknown value")
+
+	// reading past the last written offset doesn't write anything
+	assert.Equal(t, UnknownValue, segment.Peek(5000))
+	assert.Equal(t, uint64(1001), segment.Len())
+
+	// a sparse cell can be written once, and rewritten with the same value only
+	require.NoError(t, segment.Write(8, memoryValuePointerFromInt(9)))
+	require.NoError(t, segment.Write(8, memoryValuePointerFromInt(9)))
+	assert.ErrorContains(t, segment.Write(8, memoryValuePointerFromInt(10)), "rewriting value")
+}
+
 func TestMemoryWriteAndRead(t *testing.T) {
 	memory := InitializeEmptyMemory()
 	memory.AllocateEmptySegment()
@@ -138,6 +176,110 @@ func TestMemoryWriteAndRead(t *testing.T) {
 	assert.Equal(t, val, MemoryValueFromInt(31))
 }
 
+func TestMemoryMaxSegmentSize(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.MaxSegmentSize = 4
+	memory.AllocateEmptySegment()
+	memory.AllocateEmptySegment()
+
+	err := memory.Write(0, 3, memoryValuePointerFromInt(1))
+	require.NoError(t, err)
+	err = memory.WriteToAddress(&MemoryAddress{1, 1}, memoryValuePointerFromInt(2))
+	require.NoError(t, err)
+
+	err = memory.WriteToAddress(&MemoryAddress{0, 4}, memoryValuePointerFromInt(3))
+	require.ErrorContains(t, err, "exceeds the maximum segment size of 4")
+	assert.False(t, memory.KnownValue(0, 4))
+
+	assert.Equal(t, map[uint64]uint64{0: 4, 1: 2}, memory.SegmentSizes())
+}
+
+func TestMemoryAccessedAddresses(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	memory.AllocateEmptySegment()
+
+	require.NoError(t, memory.Write(0, 0, memoryValuePointerFromInt(1)))
+	assert.Nil(t, memory.AccessedAddresses())
+
+	memory.TrackAccesses()
+	require.NoError(t, memory.Write(0, 2, memoryValuePointerFromInt(2)))
+	_, err := memory.Read(0, 0)
+	require.NoError(t, err)
+	_, err = memory.PeekFromAddress(&MemoryAddress{1, 3})
+	require.NoError(t, err)
+	// failed accesses aren't recorded
+	require.Error(t, memory.Write(0, 2, memoryValuePointerFromInt(3)))
+	_, err = memory.Read(2, 0)
+	require.Error(t, err)
+
+	assert.Equal(t, map[uint64]map[uint64]struct{}{
+		0: {0: {}, 2: {}},
+	}, memory.AccessedAddresses())
+}
+
+func TestMemoryHoles(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	memory.AllocateEmptySegment()
+	memory.AllocateEmptySegment()
+
+	// segment 0 has an interior gap at offsets 1 and 2
+	require.NoError(t, memory.Write(0, 0, memoryValuePointerFromInt(1)))
+	require.NoError(t, memory.Write(0, 3, memoryValuePointerFromInt(4)))
+	// segment 1 is contiguous
+	require.NoError(t, memory.Write(1, 0, memoryValuePointerFromInt(5)))
+	require.NoError(t, memory.Write(1, 1, memoryValuePointerFromInt(6)))
+	// segment 2 is empty
+	// segment 3 is a builtin segment with a gap at offset 1
+	memory.AllocateBuiltinSegment(&testBuiltin{})
+	require.NoError(t, memory.Write(3, 0, memoryValuePointerFromInt(7)))
+	require.NoError(t, memory.Write(3, 2, memoryValuePointerFromInt(8)))
+
+	// the cells allocated past the last written offset are not holes
+	assert.Greater(t, memory.Segments[0].RealLen(), memory.Segments[0].Len())
+	assert.Equal(t, map[uint64]uint64{0: 2, 1: 0, 2: 0, 3: 1}, memory.CountHoles())
+
+	// builtin segments are not filled
+	require.NoError(t, memory.FillHoles(MemoryValueFromInt(0)))
+	assert.Equal(t, map[uint64]uint64{0: 0, 1: 0, 2: 0, 3: 1}, memory.CountHoles())
+	mv := memory.Segments[3].Peek(1)
+	assert.False(t, mv.Known())
+
+	for offset, expected := range []uint64{1, 0, 0, 4} {
+		mv, err := memory.Read(0, uint64(offset))
+		require.NoError(t, err)
+		assert.Equal(t, MemoryValueFromUint(expected), mv)
+	}
+	assert.Equal(t, map[uint64]uint64{0: 4, 1: 2, 2: 0, 3: 3}, memory.SegmentSizes())
+}
+
+func TestMemoryValidateRelocatables(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	memory.AllocateEmptySegment()
+
+	require.NoError(t, memory.Write(1, 0, memoryValuePointerFromInt(7)))
+	require.NoError(t, memory.Write(1, 1, memoryValuePointerFromInt(8)))
+
+	// a pointer inside a segment and a pointer right after its last cell are valid
+	inside := MemoryValueFromSegmentAndOffset(1, 1)
+	end := MemoryValueFromSegmentAndOffset(1, 2)
+	require.NoError(t, memory.Write(0, 0, &inside))
+	require.NoError(t, memory.Write(0, 1, &end))
+	assert.Empty(t, memory.ValidateRelocatables())
+
+	missing := MemoryValueFromSegmentAndOffset(5, 0)
+	outOfBounds := MemoryValueFromSegmentAndOffset(1, 3)
+	require.NoError(t, memory.Write(0, 2, &missing))
+	require.NoError(t, memory.Write(0, 3, &outOfBounds))
+
+	errs := memory.ValidateRelocatables()
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "segment 0 offset 2: relocatable 5:0 points to an unallocated segment")
+	assert.EqualError(t, errs[1], "segment 0 offset 3: relocatable 1:3 is out of the bounds of its segment of size 2")
+}
+
 func TestMemoryReadUnallocated(t *testing.T) {
 	memory := InitializeEmptyMemory()
 	memory.AllocateEmptySegment()
@@ -160,6 +302,29 @@ func TestMemoryPeek(t *testing.T) {
 	assert.Equal(t, MemoryValueFromInt(412), mv)
 }
 
+func TestMemoryPeekKnown(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	memory.AllocateBuiltinSegment(&testBuiltin{})
+	err := memory.Write(0, 1, memoryValuePointerFromInt(412))
+	assert.NoError(t, err)
+
+	mv, ok := memory.PeekKnown(&MemoryAddress{0, 1})
+	assert.True(t, ok)
+	assert.Equal(t, MemoryValueFromInt(412), mv)
+
+	_, ok = memory.PeekKnown(&MemoryAddress{0, 0})
+	assert.False(t, ok)
+
+	// peeking doesn't run the builtin inference
+	_, ok = memory.PeekKnown(&MemoryAddress{1, 0})
+	assert.False(t, ok)
+	assert.False(t, memory.KnownValue(1, 0))
+
+	_, ok = memory.PeekKnown(&MemoryAddress{2, 0})
+	assert.False(t, ok)
+}
+
 type testBuiltin struct{}
 
 func (b *testBuiltin) CheckWrite(segment *Segment, offset uint64, value *MemoryValue) error {
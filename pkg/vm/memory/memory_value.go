@@ -40,6 +40,9 @@ func (address *MemoryAddress) AddOffset(offset int16) (MemoryAddress, error) {
 	}, nil
 }
 
+// SubAddress returns the pointer difference `address - other`, i.e. the number of cells
+// from `other` to `address`. Both addresses must belong to the same segment and `other`
+// must not come after `address`
 func (address *MemoryAddress) SubAddress(other *MemoryAddress) (uint64, error) {
 	if address.SegmentIndex != other.SegmentIndex {
 		return 0, fmt.Errorf("cannot subtract addresses from different segments: %d != %d",
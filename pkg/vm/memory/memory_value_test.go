@@ -146,7 +146,46 @@ func TestMemoryAddressSubMemoryAddressDiffSegment(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestMemoryAddressSubAddress(t *testing.T) {
+	start := MemoryAddress{SegmentIndex: 2, Offset: 4}
+	end := MemoryAddress{SegmentIndex: 2, Offset: 13}
+
+	diff, err := end.SubAddress(&start)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), diff)
+
+	diff, err = start.SubAddress(&start)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), diff)
+
+	// a negative difference is not an offset
+	_, err = start.SubAddress(&end)
+	require.ErrorContains(t, err, "cannot subtract addresses: 4 < 13")
+
+	other := MemoryAddress{SegmentIndex: 3, Offset: 4}
+	_, err = end.SubAddress(&other)
+	require.ErrorContains(t, err, "cannot subtract addresses from different segments: 2 != 3")
+}
+
 func memoryValuePointerFromInt[T constraints.Integer](v T) *MemoryValue {
 	mv := MemoryValueFromInt(v)
 	return &mv
 }
+
+func TestMemoryValueFromAddressConstructors(t *testing.T) {
+	fromSegmentAndOffset := MemoryValueFromSegmentAndOffset(uint64(3), uint64(7))
+	fromAddress := MemoryValueFromMemoryAddress(&MemoryAddress{SegmentIndex: 3, Offset: 7})
+
+	for _, value := range []MemoryValue{fromSegmentAndOffset, fromAddress} {
+		assert.True(t, value.IsAddress())
+		assert.False(t, value.IsFelt())
+
+		address, err := value.MemoryAddress()
+		require.NoError(t, err)
+		assert.Equal(t, MemoryAddress{SegmentIndex: 3, Offset: 7}, *address)
+
+		_, err = value.FieldElement()
+		require.Error(t, err)
+	}
+	assert.Equal(t, fromAddress, fromSegmentAndOffset)
+}
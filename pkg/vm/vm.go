@@ -2,8 +2,11 @@ package vm
 
 import (
 	"encoding/binary"
+	"encoding/csv"
 	"fmt"
+	"io"
 	"math"
+	"strconv"
 
 	a "github.com/NethermindEth/cairo-vm-go/pkg/assembler"
 	"github.com/NethermindEth/cairo-vm-go/pkg/utils"
@@ -52,15 +55,35 @@ func (ctx *Context) AddressPc() mem.MemoryAddress {
 	return mem.MemoryAddress{SegmentIndex: ctx.Pc.SegmentIndex, Offset: ctx.Pc.Offset}
 }
 
-// relocates pc, ap and fp to be their real address value
-// that is, pc + 1, ap + programSegmentOffset, fp + programSegmentOffset
-func (ctx *Context) Relocate(executionSegmentOffset uint64) Trace {
+// relocates pc, ap and fp to be their real address value, given the relocation
+// table mapping each segment index to the absolute address of its base
+func (ctx *Context) Relocate(relocationTable []uint64) (Trace, error) {
+	if ctx.Pc.SegmentIndex >= uint64(len(relocationTable)) {
+		return Trace{}, fmt.Errorf("pc %s: segment %d is not in the relocation table", ctx.Pc, ctx.Pc.SegmentIndex)
+	}
+	// ap and fp always point to the execution segment
+	if ExecutionSegment >= len(relocationTable) {
+		return Trace{}, fmt.Errorf("execution segment %d is not in the relocation table", ExecutionSegment)
+	}
 	return Trace{
-		// todo(rodro): this should be improved upon
-		Pc: ctx.Pc.Offset + 1,
-		Ap: ctx.Ap + executionSegmentOffset,
-		Fp: ctx.Fp + executionSegmentOffset,
+		Pc: relocationTable[ctx.Pc.SegmentIndex] + ctx.Pc.Offset,
+		Ap: relocationTable[ExecutionSegment] + ctx.Ap,
+		Fp: relocationTable[ExecutionSegment] + ctx.Fp,
+	}, nil
+}
+
+// RelocateTrace converts the registers of every trace entry to absolute addresses.
+// The relocation table is the one returned by `Memory.RelocationOffsets`
+func RelocateTrace(trace []Context, relocationTable []uint64) ([]Trace, error) {
+	relocatedTrace := make([]Trace, len(trace))
+	for i := range trace {
+		var err error
+		relocatedTrace[i], err = trace[i].Relocate(relocationTable)
+		if err != nil {
+			return nil, fmt.Errorf("trace entry %d: %w", i, err)
+		}
 	}
+	return relocatedTrace, nil
 }
 
 type Trace struct {
@@ -73,6 +96,9 @@ type Trace struct {
 type VirtualMachineConfig struct {
 	// If true, the vm outputs the trace and the relocated memory at the end of execution
 	ProofMode bool
+	// If true, the vm records the context of every step, even outside of proof mode.
+	// Proof mode always records it since the trace is part of the proof
+	CollectTrace bool
 }
 
 type VirtualMachine struct {
@@ -91,9 +117,13 @@ type VirtualMachine struct {
 func NewVirtualMachine(
 	initialContext Context, memory *mem.Memory, config VirtualMachineConfig,
 ) (*VirtualMachine, error) {
+	if config.ProofMode {
+		config.CollectTrace = true
+	}
+
 	// Initialize the trace if necesary
 	var trace []Context
-	if config.ProofMode {
+	if config.CollectTrace {
 		trace = make([]Context, 0)
 	}
 
@@ -136,7 +166,7 @@ func (vm *VirtualMachine) RunStep(hintRunner HintRunner) error {
 	}
 
 	// store the trace before state change
-	if vm.config.ProofMode {
+	if vm.config.CollectTrace {
 		vm.Trace = append(vm.Trace, vm.Context)
 	}
 
@@ -218,11 +248,12 @@ func (vm *VirtualMachine) RunInstruction(instruction *a.Instruction) error {
 
 // It returns the current trace entry, the public memory, and the occurrence of an error
 func (vm *VirtualMachine) ExecutionTrace() ([]Trace, error) {
-	if !vm.config.ProofMode {
-		return nil, fmt.Errorf("proof mode is off")
+	if !vm.config.CollectTrace {
+		return nil, fmt.Errorf("trace collection is off")
 	}
 
-	return vm.relocateTrace(), nil
+	relocationTable, _ := vm.Memory.RelocationOffsets()
+	return RelocateTrace(vm.Trace, relocationTable)
 }
 
 func (vm *VirtualMachine) getDstAddr(instruction *a.Instruction) (mem.MemoryAddress, error) {
@@ -537,17 +568,6 @@ func (vm *VirtualMachine) updateFp(instruction *a.Instruction, dstAddr *mem.Memo
 	}
 }
 
-func (vm *VirtualMachine) relocateTrace() []Trace {
-	// one is added, because prover expect that the first element to be on
-	// indexed on 1 instead of 0
-	relocatedTrace := make([]Trace, len(vm.Trace))
-	totalBytecode := vm.Memory.Segments[ProgramSegment].Len() + 1
-	for i := range vm.Trace {
-		relocatedTrace[i] = vm.Trace[i].Relocate(totalBytecode)
-	}
-	return relocatedTrace
-}
-
 // It returns all segments in memory but relocated as a single segment
 // Each element is a pointer to a field element, if the cell was not accessed,
 // nil is stored instead
@@ -604,6 +624,82 @@ func DecodeTrace(content []byte) []Trace {
 	return trace
 }
 
+// WriteTraceCSV writes the trace as CSV, one `step,pc,ap,fp` row per entry in
+// decimal after a header row, for a quick inspection in a spreadsheet
+func WriteTraceCSV(w io.Writer, trace []Trace) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"step", "pc", "ap", "fp"}); err != nil {
+		return err
+	}
+	for i := range trace {
+		row := []string{
+			strconv.Itoa(i),
+			strconv.FormatUint(trace[i].Pc, 10),
+			strconv.FormatUint(trace[i].Ap, 10),
+			strconv.FormatUint(trace[i].Fp, 10),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// DeltaTrace is a compact in-memory representation of a trace. Every entry is stored
+// as the varint encoded differences between its registers and the ones of the previous
+// entry, which mostly take a single byte each since the registers move by small steps
+type DeltaTrace struct {
+	deltas []byte
+	length int
+}
+
+// DeltaEncodeTrace compresses the trace into a DeltaTrace
+func DeltaEncodeTrace(trace []Trace) DeltaTrace {
+	deltas := make([]byte, 0, len(trace)*3)
+	var previous Trace
+	for i := range trace {
+		// the differences wrap around, which the decoding undoes
+		deltas = binary.AppendVarint(deltas, int64(trace[i].Pc-previous.Pc))
+		deltas = binary.AppendVarint(deltas, int64(trace[i].Fp-previous.Fp))
+		deltas = binary.AppendVarint(deltas, int64(trace[i].Ap-previous.Ap))
+		previous = trace[i]
+	}
+	return DeltaTrace{deltas: deltas, length: len(trace)}
+}
+
+// Len returns the number of entries of the trace
+func (trace *DeltaTrace) Len() int {
+	return trace.length
+}
+
+// Size returns the number of bytes used to store the trace entries
+func (trace *DeltaTrace) Size() int {
+	return len(trace.deltas)
+}
+
+// Decode rebuilds the trace entries
+func (trace *DeltaTrace) Decode() []Trace {
+	decoded := make([]Trace, trace.length)
+	var previous Trace
+	content := trace.deltas
+	for i := range decoded {
+		var registers [3]uint64
+		for j := range registers {
+			delta, n := binary.Varint(content)
+			registers[j] = uint64(delta)
+			content = content[n:]
+		}
+		decoded[i] = Trace{
+			Pc: previous.Pc + registers[0],
+			Fp: previous.Fp + registers[1],
+			Ap: previous.Ap + registers[2],
+		}
+		previous = decoded[i]
+	}
+	return decoded
+}
+
 const addrSize = 8
 const feltSize = 32
 
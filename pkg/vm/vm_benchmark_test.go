@@ -122,3 +122,27 @@ func BenchmarkAssertEqual(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkDeltaTrace(b *testing.B) {
+	// a loop of 4 instructions pushing a value every step
+	trace := make([]Trace, 1<<20)
+	for i := range trace {
+		trace[i] = Trace{Pc: uint64(i % 4), Fp: 2, Ap: uint64(2 + i)}
+	}
+
+	b.Run("encode", func(b *testing.B) {
+		var deltaTrace DeltaTrace
+		for i := 0; i < b.N; i++ {
+			deltaTrace = DeltaEncodeTrace(trace)
+		}
+		b.ReportMetric(float64(deltaTrace.Size())/float64(len(trace)), "bytes/entry")
+		b.ReportMetric(float64(ctxSize), "raw-bytes/entry")
+	})
+	b.Run("decode", func(b *testing.B) {
+		deltaTrace := DeltaEncodeTrace(trace)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			deltaTrace.Decode()
+		}
+	})
+}
@@ -1,7 +1,9 @@
 package vm
 
 import (
+	"bytes"
 	"encoding/binary"
+	"math"
 	"testing"
 
 	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
@@ -1245,6 +1247,33 @@ func TestMemoryRelocationWithAddress(t *testing.T) {
 	require.Equal(t, expected, res)
 }
 
+func TestRelocateTrace(t *testing.T) {
+	// program segment of 5 cells, execution segment of 20 cells and a third
+	// segment holding a function called through a pointer
+	relocationTable := []uint64{1, 6, 26, 30}
+
+	trace := []Context{
+		{Pc: mem.MemoryAddress{SegmentIndex: ProgramSegment, Offset: 0}, Ap: 2, Fp: 2},
+		{Pc: mem.MemoryAddress{SegmentIndex: ProgramSegment, Offset: 3}, Ap: 5, Fp: 2},
+		{Pc: mem.MemoryAddress{SegmentIndex: 2, Offset: 1}, Ap: 9, Fp: 7},
+	}
+
+	relocatedTrace, err := RelocateTrace(trace, relocationTable)
+	require.NoError(t, err)
+	require.Equal(t, []Trace{
+		{Pc: 1, Ap: 8, Fp: 8},
+		{Pc: 4, Ap: 11, Fp: 8},
+		{Pc: 27, Ap: 15, Fp: 13},
+	}, relocatedTrace)
+
+	trace = append(trace, Context{Pc: mem.MemoryAddress{SegmentIndex: 4, Offset: 0}, Ap: 10, Fp: 7})
+	_, err = RelocateTrace(trace, relocationTable)
+	require.ErrorContains(t, err, "trace entry 3: pc 4:0: segment 4 is not in the relocation table")
+
+	_, err = RelocateTrace(trace[:1], relocationTable[:1])
+	require.ErrorContains(t, err, "trace entry 0: execution segment 1 is not in the relocation table")
+}
+
 // ==============================
 // Test Trace and Memory Encoding
 // ==============================
@@ -1289,6 +1318,38 @@ func TestTraceEncodingDecoding(t *testing.T) {
 
 }
 
+func TestDeltaTraceRoundTrip(t *testing.T) {
+	trace := []Trace{
+		{Ap: 102, Fp: 102, Pc: 10},
+		{Ap: 103, Fp: 102, Pc: 12},
+		// a call, then a return jumping back
+		{Ap: 105, Fp: 105, Pc: 40},
+		{Ap: 106, Fp: 102, Pc: 14},
+		{Ap: 0, Fp: math.MaxUint64, Pc: 1 << 40},
+	}
+
+	deltaTrace := DeltaEncodeTrace(trace)
+	assert.Equal(t, len(trace), deltaTrace.Len())
+	assert.Less(t, deltaTrace.Size(), len(EncodeTrace(trace)))
+	require.Equal(t, trace, deltaTrace.Decode())
+
+	empty := DeltaEncodeTrace(nil)
+	assert.Equal(t, 0, empty.Size())
+	assert.Empty(t, empty.Decode())
+}
+
+func TestWriteTraceCSV(t *testing.T) {
+	trace := []Trace{
+		{Ap: 102, Fp: 102, Pc: 10},
+		{Ap: 103, Fp: 102, Pc: 12},
+		{Ap: 105, Fp: 105, Pc: 40},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTraceCSV(&buf, trace))
+	assert.Equal(t, "step,pc,ap,fp\n0,10,102,102\n1,12,103,102\n2,40,105,105\n", buf.String())
+}
+
 func TestMemoryEncodingDecoding(t *testing.T) {
 	memory := []*f.Element{
 		new(f.Element).SetUint64(4),